@@ -0,0 +1,25 @@
+provider "longship" {}
+
+# Warn when less than 95% of the events of the last day were delivered
+check "webhook_deliveries" {
+  data "longship_webhook_deliveries" "last_day" {
+    webhook_id = longship_webhook.example.id
+    from       = timeadd(plantimestamp(), "-24h")
+  }
+
+  assert {
+    condition     = coalesce(data.longship_webhook_deliveries.last_day.success_rate, 1) >= 0.95
+    error_message = "Only ${data.longship_webhook_deliveries.last_day.successful} of ${data.longship_webhook_deliveries.last_day.total} events were delivered to the webhook in the last day."
+  }
+}
+
+# List the failed deliveries of session events
+data "longship_webhook_deliveries" "failed_sessions" {
+  webhook_id = longship_webhook.example.id
+  event_type = "SESSION_START"
+  outcome    = "failure"
+}
+
+output "failed_session_deliveries" {
+  value = data.longship_webhook_deliveries.failed_sessions.deliveries[*].error
+}
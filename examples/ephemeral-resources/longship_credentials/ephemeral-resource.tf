@@ -0,0 +1,17 @@
+# Read the credentials of the provider configuration, and pass them to another
+# provider without persisting them in the plan or state (Terraform 1.10 and
+# later)
+ephemeral "longship_credentials" "current" {}
+
+provider "restapi" {
+  uri = ephemeral.longship_credentials.current.host
+  headers = {
+    "Ocp-Apim-Subscription-Key" = ephemeral.longship_credentials.current.tenant_key
+    "x-api-key"                 = ephemeral.longship_credentials.current.application_key
+  }
+}
+
+# Read the credentials of another profile of the Longship config file
+ephemeral "longship_credentials" "reporting" {
+  profile = "reporting"
+}
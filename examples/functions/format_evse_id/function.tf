@@ -0,0 +1,4 @@
+output "evse_id" {
+  # NL*LSP*E12345*1
+  value = provider::longship::format_evse_id("NL", "LSP", "12345*1")
+}
@@ -0,0 +1,6 @@
+resource "longship_webhook" "example" {
+  name        = "sessions"
+  ou_code     = provider::longship::normalize_ou_code(var.ou_code)
+  event_types = ["SESSION_START"]
+  url         = "https://example.com"
+}
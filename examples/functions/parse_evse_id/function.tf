@@ -0,0 +1,15 @@
+data "longship_chargepoints" "all" {}
+
+# Group the EVSEs of all chargepoints by their operator
+locals {
+  evse_ids = flatten([
+    for chargepoint in data.longship_chargepoints.all.chargepoints : [
+      for evse in chargepoint.evses : evse.evse_id
+    ]
+  ])
+
+  evses_by_operator = {
+    for id in local.evse_ids :
+    provider::longship::parse_evse_id(id).operator_id => id...
+  }
+}
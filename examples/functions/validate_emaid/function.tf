@@ -0,0 +1,8 @@
+variable "contract_id" {
+  type = string
+
+  validation {
+    condition     = can(provider::longship::validate_emaid(var.contract_id))
+    error_message = "The contract ID must be a valid eMAID, e.g. NL-TNM-012345678-9."
+  }
+}
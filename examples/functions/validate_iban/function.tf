@@ -0,0 +1,8 @@
+variable "iban" {
+  type = string
+
+  validation {
+    condition     = can(provider::longship::validate_iban(var.iban))
+    error_message = "The IBAN is invalid, check for typos."
+  }
+}
@@ -0,0 +1,9 @@
+# List the webhooks of an OU which are configured for session events
+list "longship_webhook" "sessions" {
+  provider = longship
+
+  config {
+    ou_code    = "0000"
+    event_type = "SESSION_START"
+  }
+}
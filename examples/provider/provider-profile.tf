@@ -0,0 +1,3 @@
+provider "longship" {
+  profile = "prod"
+}
@@ -0,0 +1,19 @@
+# Webhooks can be imported with an import block by their resource identity
+# (Terraform 1.12 and later)
+import {
+  to = longship_webhook.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+# The host and tenant are optional. When set, the import fails if the provider
+# is configured for a different Longship host or tenant.
+import {
+  to = longship_webhook.other
+  identity = {
+    host   = "https://prod.longship.example"
+    tenant = "29720d5beca45c54"
+    id     = "11111111-1111-1111-1111-111111111111"
+  }
+}
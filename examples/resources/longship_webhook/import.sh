@@ -1,2 +1,6 @@
 # Webhooks can be imported by specifying the unique identifier
 terraform import longship_webhook.example 00000000-0000-0000-0000-000000000000
+
+# Webhooks can also be imported by their OU code and name, as long as the
+# name is unique within the OU
+terraform import longship_webhook.example 0000/test
@@ -7,7 +7,16 @@ resource "longship_webhook" "example" {
   event_types = ["SESSION_START"]
   url         = "https://example.com"
   headers = {
-    hello = "world"
+    hello         = "world"
+    Authorization = "Bearer ${var.webhook_token}"
+  }
+  write_only_headers = ["Authorization"]
+
+  # Send a signed test event to the url after every create and update, and
+  # warn when the endpoint does not accept it
+  verify {
+    timeout = "30s"
+    secret  = var.webhook_signing_secret
   }
 }
 
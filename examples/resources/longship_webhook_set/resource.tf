@@ -0,0 +1,20 @@
+provider "longship" {}
+
+resource "longship_webhook_set" "example" {
+  template = {
+    name        = "sessions"
+    event_types = ["SESSION_START", "SESSION_STOP"]
+    url         = "https://example.com"
+    headers = {
+      Authorization = "Bearer ${var.webhook_token}"
+    }
+  }
+
+  # Adding or removing a code only creates or deletes the webhook in that
+  # organizational unit
+  ou_codes = ["0000", "0001", "0002"]
+}
+
+output "webhook_ids" {
+  value = longship_webhook_set.example.webhook_ids
+}
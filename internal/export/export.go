@@ -0,0 +1,282 @@
+// Package export generates Terraform configuration for the existing objects
+// of a Longship tenant, so that objects created outside of Terraform can be
+// brought under management in bulk.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+const (
+	// webhooksFile holds the generated longship_webhook resources.
+	webhooksFile = "longship_webhook.tf"
+
+	// importsFile holds the import blocks of all generated resources.
+	importsFile = "imports.tf"
+
+	// variablesFile holds the variables of secret values, such as webhook
+	// header values, which are never written to the generated files.
+	variablesFile = "variables.tf"
+
+	header = "# Generated by terraform-provider-longship export.\n\n"
+)
+
+// Run enumerates the tenant of client and writes the configuration of its
+// webhooks to dir, along with import blocks adopting them into the state. It
+// returns the paths of the written files, and warnings about objects which
+// were skipped or need to be completed by hand. Existing files are only
+// overwritten when force is set.
+func Run(ctx context.Context, client *longship.Client, dir string, force bool) ([]string, []string, error) {
+
+	webhooks, err := listWebhooks(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	generated := generateWebhooks(webhooks)
+
+	files := map[string][]byte{
+		filepath.Join(dir, webhooksFile): append([]byte(header), generated.resources.Bytes()...),
+		filepath.Join(dir, importsFile):  append([]byte(header), generated.imports.Bytes()...),
+	}
+
+	if len(generated.variables.Body().Blocks()) > 0 {
+		files[filepath.Join(dir, variablesFile)] = append([]byte(header), generated.variables.Bytes()...)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if !force {
+		for _, path := range paths {
+			if _, err := os.Stat(path); err == nil {
+				return nil, nil, fmt.Errorf("file %s already exists, remove it or use -force to overwrite it", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+
+	// The files describe the tenant, so they are only readable by the current
+	// user. WriteFile keeps the mode of files which are overwritten, hence the
+	// explicit Chmod.
+	for _, path := range paths {
+		if err := os.WriteFile(path, files[path], 0o600); err != nil {
+			return nil, nil, err
+		}
+		if err := os.Chmod(path, 0o600); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return paths, generated.warnings, nil
+}
+
+// listWebhooks returns all webhooks of the tenant including their headers,
+// ordered by OU code, name and ID so that the generated configuration is
+// deterministic.
+func listWebhooks(ctx context.Context, client *longship.Client) ([]longship.WebhookResponse, error) {
+
+	webhooks, err := client.Webhooks.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list webhooks: %w", err)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		a, b := webhooks[i], webhooks[j]
+		if a.OUCode != b.OUCode {
+			return a.OUCode < b.OUCode
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	// Only single webhooks are returned with their headers.
+	result := make([]longship.WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		webhook, err := client.Webhooks.Get(ctx, w.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read webhook %s: %w", w.ID, err)
+		}
+		result = append(result, *webhook)
+	}
+
+	return result, nil
+}
+
+// generatedWebhooks is the configuration generated for webhooks.
+type generatedWebhooks struct {
+	resources *hclwrite.File
+	imports   *hclwrite.File
+	variables *hclwrite.File
+	warnings  []string
+}
+
+// generateWebhooks returns the longship_webhook resources and their import
+// blocks for webhooks. Header values are secrets such as bearer tokens, so
+// each header references a sensitive variable instead of holding its value.
+// Webhooks without event types are skipped, as the resource requires at least
+// one.
+func generateWebhooks(webhooks []longship.WebhookResponse) generatedWebhooks {
+
+	generated := generatedWebhooks{
+		resources: hclwrite.NewEmptyFile(),
+		imports:   hclwrite.NewEmptyFile(),
+		variables: hclwrite.NewEmptyFile(),
+	}
+	names := resourceNames{}
+	variableNames := resourceNames{}
+
+	for _, w := range webhooks {
+		if len(w.EventTypes) == 0 {
+			generated.warnings = append(generated.warnings, fmt.Sprintf(
+				"Skipped webhook %s (%q in OU %s), which has no event types: longship_webhook requires at least one.",
+				w.ID, w.Name, w.OUCode))
+			continue
+		}
+
+		name := names.unique(resourceName("webhook", w.OUCode, w.Name))
+
+		if len(generated.resources.Body().Blocks()) > 0 {
+			generated.resources.Body().AppendNewline()
+			generated.imports.Body().AppendNewline()
+		}
+
+		body := generated.resources.Body().AppendNewBlock("resource", []string{"longship_webhook", name}).Body()
+		body.SetAttributeValue("name", cty.StringVal(w.Name))
+		body.SetAttributeValue("ou_code", cty.StringVal(w.OUCode))
+		body.SetAttributeValue("enabled", cty.BoolVal(w.Enabled))
+		body.SetAttributeValue("url", cty.StringVal(w.URL))
+
+		// Keep existing webhooks with an http url valid, which the provider
+		// otherwise rejects.
+		if strings.HasPrefix(strings.ToLower(w.URL), "http://") {
+			body.SetAttributeValue("allow_insecure_url", cty.True)
+		}
+
+		eventTypes := make([]cty.Value, 0, len(w.EventTypes))
+		for _, eventType := range w.EventTypes {
+			eventTypes = append(eventTypes, cty.StringVal(eventType))
+		}
+		body.SetAttributeValue("event_types", cty.SetVal(eventTypes))
+
+		if len(w.Headers) > 0 {
+			headers := slices.Clone(w.Headers)
+			sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+
+			attrs := make([]hclwrite.ObjectAttrTokens, 0, len(headers))
+			for _, h := range headers {
+				variable := variableNames.unique(name + "_header_" + resourceName("header", h.Name))
+
+				if len(generated.variables.Body().Blocks()) > 0 {
+					generated.variables.Body().AppendNewline()
+				}
+				block := generated.variables.Body().AppendNewBlock("variable", []string{variable}).Body()
+				block.SetAttributeValue("description", cty.StringVal(fmt.Sprintf("Value of header %s of webhook %q in OU %s.", h.Name, w.Name, w.OUCode)))
+				block.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+				block.SetAttributeValue("sensitive", cty.True)
+
+				key := hclwrite.TokensForValue(cty.StringVal(h.Name))
+				if hclsyntax.ValidIdentifier(h.Name) {
+					key = hclwrite.TokensForIdentifier(h.Name)
+				}
+
+				attrs = append(attrs, hclwrite.ObjectAttrTokens{
+					Name: key,
+					Value: hclwrite.TokensForTraversal(hcl.Traversal{
+						hcl.TraverseRoot{Name: "var"},
+						hcl.TraverseAttr{Name: variable},
+					}),
+				})
+			}
+			body.SetAttributeRaw("headers", hclwrite.TokensForObject(attrs))
+		}
+
+		block := generated.imports.Body().AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: "longship_webhook"},
+			hcl.TraverseAttr{Name: name},
+		})
+		block.SetAttributeValue("id", cty.StringVal(w.ID))
+	}
+
+	if blocks := generated.variables.Body().Blocks(); len(blocks) > 0 {
+		generated.warnings = append(generated.warnings, fmt.Sprintf(
+			"Webhook header values are not exported. Set the %d sensitive variables declared in %s, e.g. with TF_VAR_ environment variables.",
+			len(blocks), variablesFile))
+	}
+
+	return generated
+}
+
+// resourceName joins parts into a valid Terraform resource name, lowercasing
+// them and replacing runs of other characters than letters and digits with
+// an underscore. Names which would not start with a letter are prefixed with
+// fallback, which is also used when nothing remains.
+func resourceName(fallback string, parts ...string) string {
+
+	var b strings.Builder
+	underscore := false
+
+	for _, r := range strings.ToLower(strings.Join(parts, "_")) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+
+	name := b.String()
+	if name == "" {
+		return fallback
+	}
+
+	if name[0] >= '0' && name[0] <= '9' {
+		return fallback + "_" + name
+	}
+
+	return name
+}
+
+// resourceNames tracks the names used for resources of a single type.
+type resourceNames map[string]bool
+
+// unique returns name, suffixed with a counter when it is already used.
+func (n resourceNames) unique(name string) string {
+
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = name + "_" + strconv.Itoa(i)
+	}
+
+	n[candidate] = true
+	return candidate
+}
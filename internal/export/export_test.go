@@ -0,0 +1,193 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+const expectedWebhooks = `# Generated by terraform-provider-longship export.
+
+resource "longship_webhook" "webhook_0000_status" {
+  name        = "Status"
+  ou_code     = "0000"
+  enabled     = true
+  url         = "https://example.com/status"
+  event_types = ["OPERATIONAL_STATUS"]
+}
+
+resource "longship_webhook" "ou_1_sessions" {
+  name        = "Sessions"
+  ou_code     = "OU-1"
+  enabled     = true
+  url         = "https://example.com/sessions"
+  event_types = ["SESSION_START", "SESSION_STOP"]
+  headers = {
+    Authorization = var.ou_1_sessions_header_authorization
+    X-Api-Key     = var.ou_1_sessions_header_x_api_key
+  }
+}
+
+resource "longship_webhook" "ou_1_sessions_2" {
+  name               = "sessions"
+  ou_code            = "OU-1"
+  enabled            = false
+  url                = "http://example.com/sessions"
+  allow_insecure_url = true
+  event_types        = ["CDR_CREATED"]
+}
+`
+
+const expectedVariables = `# Generated by terraform-provider-longship export.
+
+variable "ou_1_sessions_header_authorization" {
+  description = "Value of header Authorization of webhook \"Sessions\" in OU OU-1."
+  type        = string
+  sensitive   = true
+}
+
+variable "ou_1_sessions_header_x_api_key" {
+  description = "Value of header X-Api-Key of webhook \"Sessions\" in OU OU-1."
+  type        = string
+  sensitive   = true
+}
+`
+
+const expectedImports = `# Generated by terraform-provider-longship export.
+
+import {
+  to = longship_webhook.webhook_0000_status
+  id = "00000000-0000-0000-0000-000000000003"
+}
+
+import {
+  to = longship_webhook.ou_1_sessions
+  id = "00000000-0000-0000-0000-000000000001"
+}
+
+import {
+  to = longship_webhook.ou_1_sessions_2
+  id = "00000000-0000-0000-0000-000000000002"
+}
+`
+
+func TestRun(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": "00000000-0000-0000-0000-000000000003", "name": "Status", "ouCode": "0000"},
+			{"id": "00000000-0000-0000-0000-000000000002", "name": "sessions", "ouCode": "OU-1"},
+			{"id": "00000000-0000-0000-0000-000000000001", "name": "Sessions", "ouCode": "OU-1"},
+			{"id": "00000000-0000-0000-0000-000000000004", "name": "Empty", "ouCode": "OU-1"}
+		]`))
+	})
+
+	for id, body := range map[string]string{
+		"00000000-0000-0000-0000-000000000001": `{"id": "00000000-0000-0000-0000-000000000001", "name": "Sessions", "ouCode": "OU-1", "enabled": true, "url": "https://example.com/sessions", "eventTypes": ["SESSION_STOP", "SESSION_START"], "headers": [{"name": "X-Api-Key", "value": "secret-key"}, {"name": "Authorization", "value": "Bearer secret-token"}]}`,
+		"00000000-0000-0000-0000-000000000002": `{"id": "00000000-0000-0000-0000-000000000002", "name": "sessions", "ouCode": "OU-1", "enabled": false, "url": "http://example.com/sessions", "eventTypes": ["CDR_CREATED"], "headers": []}`,
+		"00000000-0000-0000-0000-000000000004": `{"id": "00000000-0000-0000-0000-000000000004", "name": "Empty", "ouCode": "OU-1", "enabled": true, "url": "https://example.com/empty", "eventTypes": []}`,
+		"00000000-0000-0000-0000-000000000003": `{"id": "00000000-0000-0000-0000-000000000003", "name": "Status", "ouCode": "0000", "enabled": true, "url": "https://example.com/status", "eventTypes": ["OPERATIONAL_STATUS"]}`,
+	} {
+		body := body
+		mux.HandleFunc("/v1/webhooks/"+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		})
+	}
+
+	client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "generated")
+
+	_, warnings, err := Run(context.Background(), client, dir, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "00000000-0000-0000-0000-000000000004") || !strings.Contains(warnings[1], variablesFile) {
+		t.Errorf("expected warnings about the skipped webhook and the header variables, got %q", warnings)
+	}
+
+	for file, expected := range map[string]string{
+		webhooksFile:  expectedWebhooks,
+		importsFile:   expectedImports,
+		variablesFile: expectedVariables,
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(string(content), "secret") {
+			t.Errorf("expected no header values in %s, got:\n%s", file, content)
+		}
+
+		if string(content) != expected {
+			t.Errorf("unexpected content of %s, expected:\n%s\ngot:\n%s", file, expected, content)
+		}
+
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("expected %s to only be readable by the current user, got mode %s", file, info.Mode().Perm())
+		}
+	}
+
+	if _, _, err := Run(context.Background(), client, dir, false); err == nil {
+		t.Error("expected error when overwriting existing files, got none")
+	}
+
+	if _, _, err := Run(context.Background(), client, dir, true); err != nil {
+		t.Errorf("unexpected error when forcing to overwrite existing files: %s", err)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	testCases := map[string]struct {
+		parts    []string
+		expected string
+	}{
+		"lowercased": {
+			parts:    []string{"OU1", "Sessions"},
+			expected: "ou1_sessions",
+		},
+		"special characters": {
+			parts:    []string{"OU-1", "  Session start / stop!"},
+			expected: "ou_1_session_start_stop",
+		},
+		"non ascii": {
+			parts:    []string{"NL", "Überweisung"},
+			expected: "nl_berweisung",
+		},
+		"leading digit": {
+			parts:    []string{"0000", "test"},
+			expected: "webhook_0000_test",
+		},
+		"empty": {
+			parts:    []string{"", "---"},
+			expected: "webhook",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := resourceName("webhook", tc.parts...); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -17,7 +19,7 @@ var (
 
 // ChargepointsDataSource is the data source implementation.
 type ChargepointsDataSource struct {
-	client *Client
+	client *longship.Client
 }
 
 type ChargepointsDataSourceModel struct {
@@ -62,17 +64,17 @@ func (d *ChargepointsDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 // Metadata returns the data source type name.
@@ -158,9 +160,12 @@ func (d *ChargepointsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 
 // Read refreshes the Terraform state with the latest data.
 func (d *ChargepointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "longship_chargepoints", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state ChargepointsDataSourceModel
 
-	chargepoints, err := d.client.GetChargepoints()
+	chargepoints, err := d.client.Chargepoints.List(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Longship Webhooks",
@@ -170,40 +175,7 @@ func (d *ChargepointsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	for _, chargepoint := range chargepoints {
-		chargepointState := ChargepointDataSourceModel{
-			ID:                    types.StringValue(chargepoint.ID),
-			ChargepointID:         types.StringValue(chargepoint.ChargepointID),
-			DateDeleted:           types.StringValue(chargepoint.DateDeleted),
-			DisplayName:           types.StringValue(chargepoint.DisplayName),
-			RoamingName:           types.StringValue(chargepoint.RoamingName),
-			ChargeBoxSerialNumber: types.StringValue(chargepoint.ChargeBoxSerialNumber),
-			ChargepointVendor:     types.StringValue(chargepoint.ChargepointVendor),
-		}
-
-		evsesState := []EvseDataSourceModel{}
-		for _, evse := range chargepoint.Evses {
-			connectorsState := []ConnectorDataSourceModel{}
-			for _, connector := range evse.Connectors {
-				connectorState := ConnectorDataSourceModel{
-					ID:                 types.StringValue(connector.ID),
-					OperationalStatus:  types.StringValue(connector.OperationalStatus),
-					Standard:           types.StringValue(connector.Standard),
-					Format:             types.StringValue(connector.Format),
-					PowerType:          types.StringValue(connector.PowerType),
-					MaxVoltage:         types.Int64Value(connector.MaxVoltage),
-					MaxAmperage:        types.Int64Value(connector.MaxAmperage),
-					MaxElectricalPower: types.Int64Value(connector.MaxElectricalPower),
-				}
-				connectorsState = append(connectorsState, connectorState)
-			}
-			evsesState = append(evsesState, EvseDataSourceModel{
-				EvseID:     types.StringValue(evse.EvseID),
-				Connectors: connectorsState,
-			})
-		}
-		chargepointState.Evses = evsesState
-
-		state.Chargepoints = append(state.Chargepoints, chargepointState)
+		state.Chargepoints = append(state.Chargepoints, flattenChargepoint(chargepoint))
 	}
 
 	// Set state
@@ -213,3 +185,41 @@ func (d *ChargepointsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 }
+
+// flattenChargepoint maps chargepoint to its data source model, with null
+// values for fields absent from the API response.
+func flattenChargepoint(chargepoint longship.Chargepoint) ChargepointDataSourceModel {
+
+	chargepointState := ChargepointDataSourceModel{
+		ID:                    types.StringValue(chargepoint.ID),
+		ChargepointID:         types.StringValue(chargepoint.ChargepointID),
+		DateDeleted:           types.StringPointerValue(chargepoint.DateDeleted),
+		DisplayName:           types.StringPointerValue(chargepoint.DisplayName),
+		RoamingName:           types.StringPointerValue(chargepoint.RoamingName),
+		ChargeBoxSerialNumber: types.StringPointerValue(chargepoint.ChargeBoxSerialNumber),
+		ChargepointVendor:     types.StringPointerValue(chargepoint.ChargepointVendor),
+		Evses:                 []EvseDataSourceModel{},
+	}
+
+	for _, evse := range chargepoint.Evses {
+		connectorsState := []ConnectorDataSourceModel{}
+		for _, connector := range evse.Connectors {
+			connectorsState = append(connectorsState, ConnectorDataSourceModel{
+				ID:                 types.StringValue(connector.ID),
+				OperationalStatus:  types.StringPointerValue(connector.OperationalStatus),
+				Standard:           types.StringPointerValue(connector.Standard),
+				Format:             types.StringPointerValue(connector.Format),
+				PowerType:          types.StringPointerValue(connector.PowerType),
+				MaxVoltage:         types.Int64PointerValue(connector.MaxVoltage),
+				MaxAmperage:        types.Int64PointerValue(connector.MaxAmperage),
+				MaxElectricalPower: types.Int64PointerValue(connector.MaxElectricalPower),
+			})
+		}
+		chargepointState.Evses = append(chargepointState.Evses, EvseDataSourceModel{
+			EvseID:     types.StringValue(evse.EvseID),
+			Connectors: connectorsState,
+		})
+	}
+
+	return chargepointState
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestFlattenChargepoint(t *testing.T) {
+	testCases := map[string]struct {
+		fixture     string
+		dateDeleted types.String
+		displayName types.String
+		standard    types.String
+		maxVoltage  types.Int64
+		maxAmperage types.Int64
+		evseCount   int
+	}{
+		"all fields": {
+			fixture: `{"id": "1", "chargePointId": "CP1", "dateDeleted": "2024-01-01T00:00:00Z", "displayName": "Garage",
+				"evses": [{"evse_id": "NL*LSP*E1", "connectors": [{"id": "1", "standard": "IEC_62196_T2", "maxVoltage": 230, "maxAmperage": 32}]}]}`,
+			dateDeleted: types.StringValue("2024-01-01T00:00:00Z"),
+			displayName: types.StringValue("Garage"),
+			standard:    types.StringValue("IEC_62196_T2"),
+			maxVoltage:  types.Int64Value(230),
+			maxAmperage: types.Int64Value(32),
+			evseCount:   1,
+		},
+		"null fields": {
+			fixture: `{"id": "1", "chargePointId": "CP1", "dateDeleted": null, "displayName": null,
+				"evses": [{"evse_id": "NL*LSP*E1", "connectors": [{"id": "1", "standard": null, "maxVoltage": null}]}]}`,
+			dateDeleted: types.StringNull(),
+			displayName: types.StringNull(),
+			standard:    types.StringNull(),
+			maxVoltage:  types.Int64Null(),
+			maxAmperage: types.Int64Null(),
+			evseCount:   1,
+		},
+		"empty and zero values": {
+			fixture: `{"id": "1", "chargePointId": "CP1", "dateDeleted": "", "displayName": "",
+				"evses": [{"evse_id": "NL*LSP*E1", "connectors": [{"id": "1", "standard": "", "maxVoltage": 0, "maxAmperage": 0}]}]}`,
+			dateDeleted: types.StringValue(""),
+			displayName: types.StringValue(""),
+			standard:    types.StringValue(""),
+			maxVoltage:  types.Int64Value(0),
+			maxAmperage: types.Int64Value(0),
+			evseCount:   1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var chargepoint longship.Chargepoint
+			if err := json.Unmarshal([]byte(tc.fixture), &chargepoint); err != nil {
+				t.Fatal(err)
+			}
+
+			state := flattenChargepoint(chargepoint)
+
+			if !state.DateDeleted.Equal(tc.dateDeleted) {
+				t.Errorf("expected date_deleted %s, got %s", tc.dateDeleted, state.DateDeleted)
+			}
+
+			if !state.DisplayName.Equal(tc.displayName) {
+				t.Errorf("expected display_name %s, got %s", tc.displayName, state.DisplayName)
+			}
+
+			if len(state.Evses) != tc.evseCount {
+				t.Fatalf("expected %d evses, got %d", tc.evseCount, len(state.Evses))
+			}
+
+			connector := state.Evses[0].Connectors[0]
+
+			if !connector.Standard.Equal(tc.standard) {
+				t.Errorf("expected standard %s, got %s", tc.standard, connector.Standard)
+			}
+
+			if !connector.MaxVoltage.Equal(tc.maxVoltage) {
+				t.Errorf("expected max_voltage %s, got %s", tc.maxVoltage, connector.MaxVoltage)
+			}
+
+			if !connector.MaxAmperage.Equal(tc.maxAmperage) {
+				t.Errorf("expected max_amperage %s, got %s", tc.maxAmperage, connector.MaxAmperage)
+			}
+		})
+	}
+}
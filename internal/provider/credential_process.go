@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// credentialProcessExpiryWindow is how long before their expiry credentials
+// of a credential process are refreshed.
+const credentialProcessExpiryWindow = time.Minute
+
+var _ longship.RefreshableCredentialsProvider = &credentialProcess{}
+
+// credentialProcess retrieves credentials by running an external command,
+// e.g. of a secrets manager, which prints them as JSON on stdout:
+//
+//	{"tenant_key": "...", "application_key": "...", "expires_at": "2023-01-01T00:00:00Z"}
+//
+// The credentials are cached until they expire.
+type credentialProcess struct {
+	command string
+
+	mu    sync.Mutex
+	creds *longship.Credentials
+}
+
+type credentialProcessOutput struct {
+	TenantKey      string `json:"tenant_key"`
+	ApplicationKey string `json:"application_key"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+func newCredentialProcess(command string) *credentialProcess {
+	return &credentialProcess{command: command}
+}
+
+// Retrieve returns the cached credentials, running the command when there
+// are none or they are about to expire.
+func (p *credentialProcess) Retrieve(ctx context.Context) (longship.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds != nil && (p.creds.ExpiresAt.IsZero() || time.Until(p.creds.ExpiresAt) > credentialProcessExpiryWindow) {
+		return *p.creds, nil
+	}
+
+	creds, err := p.run(ctx)
+	if err != nil {
+		return longship.Credentials{}, err
+	}
+
+	p.creds = &creds
+	return creds, nil
+}
+
+// Invalidate discards the cached credentials, so that the command is run
+// again on the next request.
+func (p *credentialProcess) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.creds = nil
+}
+
+func (p *credentialProcess) run(ctx context.Context) (longship.Credentials, error) {
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return longship.Credentials{}, fmt.Errorf("credential process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return longship.Credentials{}, fmt.Errorf("unable to parse credential process output as JSON: %w", err)
+	}
+
+	if output.TenantKey == "" || output.ApplicationKey == "" {
+		return longship.Credentials{}, fmt.Errorf("credential process output is missing tenant_key or application_key")
+	}
+
+	creds := longship.Credentials{
+		TenantKey:      output.TenantKey,
+		ApplicationKey: output.ApplicationKey,
+	}
+
+	if output.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, output.ExpiresAt)
+		if err != nil {
+			return longship.Credentials{}, fmt.Errorf("invalid expires_at in credential process output, expected RFC 3339 timestamp: %w", err)
+		}
+		creds.ExpiresAt = expiresAt
+	}
+
+	return creds, nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+
+	testCases := map[string]struct {
+		output  string
+		tenant  string
+		expires time.Time
+		wantErr bool
+	}{
+		"without expiry": {
+			output: `{"tenant_key": "tenant", "application_key": "application"}`,
+			tenant: "tenant",
+		},
+		"with expiry": {
+			output:  `{"tenant_key": "tenant", "application_key": "application", "expires_at": "2030-01-01T00:00:00Z"}`,
+			tenant:  "tenant",
+			expires: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"invalid json": {
+			output:  `tenant`,
+			wantErr: true,
+		},
+		"missing application key": {
+			output:  `{"tenant_key": "tenant"}`,
+			wantErr: true,
+		},
+		"invalid expiry": {
+			output:  `{"tenant_key": "tenant", "application_key": "application", "expires_at": "tomorrow"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			creds, err := newCredentialProcess(fmt.Sprintf("echo '%s'", tc.output)).Retrieve(context.Background())
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if creds.TenantKey != tc.tenant || creds.ApplicationKey != "application" {
+				t.Errorf("unexpected credentials %+v", creds)
+			}
+
+			if !creds.ExpiresAt.Equal(tc.expires) {
+				t.Errorf("expected expiry %s, got %s", tc.expires, creds.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestCredentialProcessFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+
+	_, err := newCredentialProcess("echo 'vault is sealed' >&2; exit 1").Retrieve(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestCredentialProcessCaching(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+
+	// The command counts its invocations in a file and prints the count as
+	// the tenant key.
+	counter := filepath.Join(t.TempDir(), "count")
+	command := func(expiresAt string) string {
+		return fmt.Sprintf(`echo x >> %[1]s; printf '{"tenant_key": "%%s", "application_key": "application", "expires_at": "%[2]s"}' "$(wc -l < %[1]s | tr -d ' ')"`, counter, expiresAt)
+	}
+
+	testCases := map[string]struct {
+		expiresAt  string
+		invalidate bool
+		tenant     string
+	}{
+		"cached until expiry": {
+			expiresAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+			tenant:    "1",
+		},
+		"refreshed when about to expire": {
+			expiresAt: time.Now().Add(30 * time.Second).Format(time.RFC3339),
+			tenant:    "2",
+		},
+		"refreshed after invalidation": {
+			expiresAt:  time.Now().Add(time.Hour).Format(time.RFC3339),
+			invalidate: true,
+			tenant:     "2",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := os.Remove(counter); err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+
+			p := newCredentialProcess(command(tc.expiresAt))
+
+			if _, err := p.Retrieve(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.invalidate {
+				p.Invalidate()
+			}
+
+			creds, err := p.Retrieve(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if creds.TenantKey != tc.tenant {
+				t.Errorf("expected the credentials of invocation %s, got %s", tc.tenant, creds.TenantKey)
+			}
+		})
+	}
+}
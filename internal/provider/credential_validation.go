@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// validateCredentials makes an authenticated call to the Longship API, so
+// that a wrong host or keys are reported during Configure rather than in the
+// middle of an apply.
+//
+// The API has no endpoint to read a single object without knowing its id,
+// nor paging, so the call lists all organizational units. It is the cheapest
+// call available to every key: with cache_responses enabled the response is
+// cached and reused by ou_code validation and the organizational units data
+// source, so within a run the list is usually not requested again.
+func validateCredentials(ctx context.Context, client *longship.Client) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	_, err := client.OrganizationalUnits.List(ctx)
+	if err == nil {
+		return diags
+	}
+
+	var statusErr *longship.StatusError
+	if !errors.As(err, &statusErr) {
+		diags.AddAttributeError(
+			path.Root("host"),
+			"Unable to Reach Longship API",
+			fmt.Sprintf("The provider could not connect to the Longship API at %s. ", client.HostURL())+
+				"Check that the host is correct and reachable from this machine, including any proxy settings, "+
+				"or disable this check with validate_credentials = false.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusUnauthorized:
+		diags.AddAttributeError(
+			path.Root("tenant_key"),
+			"Invalid Longship API Tenant Key",
+			fmt.Sprintf("The Longship API at %s rejected the credentials with status 401 Unauthorized. ", client.HostURL())+
+				"The tenant key is probably wrong or belongs to another environment. "+
+				"Check the tenant_key value, the LONGSHIP_TENANT_KEY environment variable, the selected profile or the output of the credential process.",
+		)
+	case http.StatusForbidden:
+		diags.AddAttributeError(
+			path.Root("application_key"),
+			"Invalid Longship API Application Key",
+			fmt.Sprintf("The Longship API at %s rejected the credentials with status 403 Forbidden. ", client.HostURL())+
+				"The application key is probably wrong, revoked, or lacks permission for this tenant. "+
+				"Check the application_key value, the LONGSHIP_APPLICATION_KEY environment variable, the selected profile or the output of the credential process.",
+		)
+	case http.StatusNotFound:
+		diags.AddAttributeError(
+			path.Root("host"),
+			"Invalid Longship API Host",
+			fmt.Sprintf("The Longship API was not found at %s, which responded with status 404 Not Found. ", client.HostURL())+
+				"The host is probably wrong, e.g. it includes a path or points to another service. "+
+				"Check the host value, the LONGSHIP_HOST environment variable or the selected profile.",
+		)
+	default:
+		diags.AddError(
+			"Unable to Validate Longship API Credentials",
+			"An unexpected error occurred when validating the credentials against the Longship API. "+
+				"Retry later, or disable this check with validate_credentials = false.\n\n"+
+				"Longship Client Error: "+err.Error(),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		closed     bool
+		summary    string
+		attribute  string
+	}{
+		"valid": {
+			statusCode: http.StatusOK,
+		},
+		"unauthorized": {
+			statusCode: http.StatusUnauthorized,
+			summary:    "Invalid Longship API Tenant Key",
+			attribute:  "tenant_key",
+		},
+		"forbidden": {
+			statusCode: http.StatusForbidden,
+			summary:    "Invalid Longship API Application Key",
+			attribute:  "application_key",
+		},
+		"not found": {
+			statusCode: http.StatusNotFound,
+			summary:    "Invalid Longship API Host",
+			attribute:  "host",
+		},
+		"server error": {
+			statusCode: http.StatusInternalServerError,
+			summary:    "Unable to Validate Longship API Credentials",
+		},
+		"unreachable": {
+			closed:    true,
+			summary:   "Unable to Reach Longship API",
+			attribute: "host",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/organizationalunits" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			t.Cleanup(server.Close)
+
+			if tc.closed {
+				server.Close()
+			}
+
+			client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags := validateCredentials(context.Background(), client)
+
+			if tc.summary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got: %v", diags)
+			}
+
+			if got := diags.Errors()[0].Summary(); got != tc.summary {
+				t.Errorf("expected summary %q, got %q", tc.summary, got)
+			}
+
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if tc.attribute == "" {
+				if ok {
+					t.Errorf("expected diagnostic without attribute, got %s", withPath.Path())
+				}
+				return
+			}
+
+			if !ok || !withPath.Path().Equal(path.Root(tc.attribute)) {
+				t.Errorf("expected diagnostic for attribute %s, got %v", tc.attribute, diags.Errors()[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &credentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &credentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithRenew     = &credentialsEphemeralResource{}
+)
+
+// credentialsSourceKey is the private data key of the source of expiring
+// credentials, which they are retrieved from again when renewed.
+const credentialsSourceKey = "source"
+
+// credentialsSource is the configuration credentials were retrieved from,
+// along with a fingerprint of the credentials.
+type credentialsSource struct {
+	Profile           string `json:"profile,omitempty"`
+	ConfigFile        string `json:"config_file,omitempty"`
+	CredentialProcess string `json:"credential_process,omitempty"`
+	Fingerprint       string `json:"fingerprint"`
+}
+
+// credentialsEphemeralModel maps the longship_credentials ephemeral resource
+// schema data.
+type credentialsEphemeralModel struct {
+	Profile           types.String `tfsdk:"profile"`
+	ConfigFile        types.String `tfsdk:"config_file"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+	Host              types.String `tfsdk:"host"`
+	TenantKey         types.String `tfsdk:"tenant_key"`
+	ApplicationKey    types.String `tfsdk:"application_key"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+}
+
+func NewCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &credentialsEphemeralResource{}
+}
+
+// credentialsEphemeralResource exposes Longship API credentials for the
+// duration of a Terraform run without persisting them in plan or state.
+type credentialsEphemeralResource struct {
+	tenant *tenant
+}
+
+// Configure adds the provider configured credentials to the ephemeral resource.
+func (e *credentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieving Longship API credentials")
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.tenant = data.tenant
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *credentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credentials"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *credentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides the Longship API credentials for the duration of a Terraform run, e.g. to pass them to another provider, without persisting them in the plan or state. " +
+			"By default the credentials of the provider configuration are returned. " +
+			"Expiring credentials are renewed shortly before `expires_at`, which fails when their source returns different credentials.",
+		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the Longship config file to read the host and credentials from, instead of the provider configuration.",
+				Optional:    true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path to the Longship config file to read `profile` from. May also be provided via LONGSHIP_CONFIG_FILE environment variable. Defaults to `~/.longship/config`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("profile")),
+				},
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "Command which prints the credentials as JSON, like the `credential_process` of the provider, to run instead of using the provider configuration. The host is taken from the provider configuration.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("profile")),
+				},
+			},
+			"host": schema.StringAttribute{
+				Description: "URI for Longship API.",
+				Computed:    true,
+			},
+			"tenant_key": schema.StringAttribute{
+				Description: "Tenant key for Longship API.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"application_key": schema.StringAttribute{
+				Description: "Application key for Longship API.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp at which the credentials of a credential process expire, null when they do not expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Open reads the credentials from the configured source. Expiring
+// credentials are renewed shortly before they expire.
+func (e *credentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+
+	ctx, span := startSpan(ctx, "longship_credentials", "Open")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var config credentialsEphemeralModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := credentialsSource{
+		Profile:           config.Profile.ValueString(),
+		CredentialProcess: config.CredentialProcess.ValueString(),
+	}
+	if !config.Profile.IsNull() {
+		source.ConfigFile = stringValueOrEnv(config.ConfigFile, "LONGSHIP_CONFIG_FILE")
+	}
+
+	host, creds, diags := e.retrieve(ctx, source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := credentialsEphemeralModel{
+		Profile:           config.Profile,
+		ConfigFile:        config.ConfigFile,
+		CredentialProcess: config.CredentialProcess,
+		Host:              types.StringNull(),
+		TenantKey:         types.StringValue(creds.TenantKey),
+		ApplicationKey:    types.StringValue(creds.ApplicationKey),
+		ExpiresAt:         types.StringNull(),
+	}
+
+	if host != "" {
+		result.Host = types.StringValue(host)
+	}
+
+	if !creds.ExpiresAt.IsZero() {
+		result.ExpiresAt = types.StringValue(creds.ExpiresAt.Format(time.RFC3339))
+	}
+
+	diags = resp.Result.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || creds.ExpiresAt.IsZero() {
+		return
+	}
+
+	source.Fingerprint = credentialsFingerprint(creds)
+	resp.Diagnostics.Append(setCredentialsSource(ctx, resp.Private, source)...)
+	resp.RenewAt = credentialsRenewAt(creds)
+}
+
+// Renew retrieves expiring credentials again from their source. The result
+// of an ephemeral resource cannot change once opened, so renewing only
+// succeeds while the source returns the same credentials with a later expiry.
+func (e *credentialsEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+
+	ctx, span := startSpan(ctx, "longship_credentials", "Renew")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	source, diags := getCredentialsSource(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || source == nil {
+		return
+	}
+
+	_, creds, diags := e.retrieve(ctx, *source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if credentialsFingerprint(creds) != source.Fingerprint {
+		resp.Diagnostics.AddError(
+			"Longship API Credentials Changed",
+			"The credentials returned by the credential process differ from those of the longship_credentials ephemeral resource, "+
+				"which cannot be updated during a Terraform run. Run Terraform again to use the new credentials.",
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Renewed Longship API credentials until %s", creds.ExpiresAt.Format(time.RFC3339)))
+
+	resp.RenewAt = credentialsRenewAt(creds)
+}
+
+// retrieve returns the host and credentials of source, from the provider
+// configuration when source has neither a credential process nor a profile.
+func (e *credentialsEphemeralResource) retrieve(ctx context.Context, source credentialsSource) (string, longship.Credentials, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	var host string
+	var creds longship.Credentials
+	var err error
+
+	switch {
+	case source.CredentialProcess != "":
+		tflog.Info(ctx, "Retrieving Longship API credentials from credential process")
+
+		if e.tenant != nil {
+			host = e.tenant.host
+		}
+		creds, err = newCredentialProcess(source.CredentialProcess).Retrieve(ctx)
+
+	case source.Profile != "":
+		tflog.Info(ctx, fmt.Sprintf("Retrieving Longship API credentials from profile %q", source.Profile))
+
+		host, creds, err = profileCredentials(ctx, source.ConfigFile, source.Profile)
+
+	default:
+		tflog.Info(ctx, "Retrieving Longship API credentials from provider configuration")
+
+		if e.tenant == nil {
+			diags.AddError(
+				"Unconfigured Longship Provider",
+				"The Longship provider is not configured, so its credentials cannot be returned. "+
+					"Set profile or credential_process, or ensure the provider configuration is known during this run.",
+			)
+			return "", longship.Credentials{}, diags
+		}
+
+		host = e.tenant.host
+		creds, err = e.tenant.credentials.Retrieve(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Retrieve Longship API Credentials",
+			err.Error(),
+		)
+	}
+
+	return host, creds, diags
+}
+
+// credentialsRenewAt returns when to renew creds, shortly before they
+// expire, or when they expire if that is sooner than the renewal would be.
+func credentialsRenewAt(creds longship.Credentials) time.Time {
+
+	renewAt := creds.ExpiresAt.Add(-credentialProcessExpiryWindow)
+	if !renewAt.After(time.Now()) {
+		return creds.ExpiresAt
+	}
+
+	return renewAt
+}
+
+// credentialsFingerprint returns a fingerprint of the tenant and application
+// key of creds, to detect changed credentials without storing them.
+func credentialsFingerprint(creds longship.Credentials) string {
+	return tenantFingerprint(creds.TenantKey + "\x00" + creds.ApplicationKey)
+}
+
+// getCredentialsSource returns the source of the credentials recorded in
+// private, or nil when they do not expire.
+func getCredentialsSource(ctx context.Context, private privateState) (*credentialsSource, diag.Diagnostics) {
+
+	value, diags := private.GetKey(ctx, credentialsSourceKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	var source credentialsSource
+	if err := json.Unmarshal(value, &source); err != nil {
+		diags.AddError(
+			"Unable to Read Private State",
+			fmt.Sprintf("Could not decode the source of the Longship API credentials: %s", err),
+		)
+		return nil, diags
+	}
+
+	return &source, diags
+}
+
+// setCredentialsSource records source in private.
+func setCredentialsSource(ctx context.Context, private privateState, source credentialsSource) diag.Diagnostics {
+
+	value, err := json.Marshal(source)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unable to Write Private State",
+			fmt.Sprintf("Could not encode the source of the Longship API credentials: %s", err),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, credentialsSourceKey, value)
+}
+
+// profileCredentials returns the host and credentials of the named profile in
+// configFile, running its credential process if it has one.
+func profileCredentials(ctx context.Context, configFile, name string) (string, longship.Credentials, error) {
+
+	profile, err := loadConfigProfile(configFile, name)
+	if err != nil {
+		return "", longship.Credentials{}, err
+	}
+
+	if profile.CredentialProcess != "" {
+		creds, err := newCredentialProcess(profile.CredentialProcess).Retrieve(ctx)
+		return profile.Host, creds, err
+	}
+
+	if profile.TenantKey == "" || profile.ApplicationKey == "" {
+		return "", longship.Credentials{}, fmt.Errorf("profile %q has no credential_process, or is missing tenant_key or application_key", name)
+	}
+
+	return profile.Host, longship.Credentials{
+		TenantKey:      profile.TenantKey,
+		ApplicationKey: profile.ApplicationKey,
+	}, nil
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestCredentialsEphemeralResourceOpen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+
+	ctx := context.Background()
+
+	configFile := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(configFile, []byte(`
+[static]
+host            = https://static.longship.example
+tenant_key      = static-tenant
+application_key = static-application
+
+[process]
+host               = https://process.longship.example
+credential_process = echo '{"tenant_key": "process-tenant", "application_key": "process-application", "expires_at": "2030-01-01T00:00:00Z"}'
+
+[incomplete]
+host       = https://incomplete.longship.example
+tenant_key = incomplete-tenant
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured := &tenant{
+		host:        "https://provider.longship.example",
+		credentials: longship.StaticCredentials{TenantKey: "provider-tenant", ApplicationKey: "provider-application"},
+	}
+
+	testCases := map[string]struct {
+		tenant            *tenant
+		profile           any
+		credentialProcess any
+		expected          credentialsEphemeralModel
+		renew             bool
+		wantErr           bool
+	}{
+		"provider configuration": {
+			tenant: configured,
+			expected: credentialsEphemeralModel{
+				Host:           types.StringValue("https://provider.longship.example"),
+				TenantKey:      types.StringValue("provider-tenant"),
+				ApplicationKey: types.StringValue("provider-application"),
+				ExpiresAt:      types.StringNull(),
+			},
+		},
+		"unconfigured provider": {
+			wantErr: true,
+		},
+		"credential process": {
+			tenant:            configured,
+			credentialProcess: `echo '{"tenant_key": "process-tenant", "application_key": "process-application"}'`,
+			expected: credentialsEphemeralModel{
+				Host:           types.StringValue("https://provider.longship.example"),
+				TenantKey:      types.StringValue("process-tenant"),
+				ApplicationKey: types.StringValue("process-application"),
+				ExpiresAt:      types.StringNull(),
+			},
+		},
+		"failing credential process": {
+			tenant:            configured,
+			credentialProcess: "exit 1",
+			wantErr:           true,
+		},
+		"profile": {
+			tenant:  configured,
+			profile: "static",
+			expected: credentialsEphemeralModel{
+				Host:           types.StringValue("https://static.longship.example"),
+				TenantKey:      types.StringValue("static-tenant"),
+				ApplicationKey: types.StringValue("static-application"),
+				ExpiresAt:      types.StringNull(),
+			},
+		},
+		"profile with credential process": {
+			profile: "process",
+			expected: credentialsEphemeralModel{
+				Host:           types.StringValue("https://process.longship.example"),
+				TenantKey:      types.StringValue("process-tenant"),
+				ApplicationKey: types.StringValue("process-application"),
+				ExpiresAt:      types.StringValue("2030-01-01T00:00:00Z"),
+			},
+			renew: true,
+		},
+		"incomplete profile": {
+			profile: "incomplete",
+			wantErr: true,
+		},
+		"missing profile": {
+			profile: "missing",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			e := &credentialsEphemeralResource{tenant: tc.tenant}
+
+			var schemaResp ephemeral.SchemaResponse
+			e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			var configFileValue any
+			if tc.profile != nil {
+				configFileValue = configFile
+			}
+
+			req := ephemeral.OpenRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+						"profile":            tftypes.NewValue(tftypes.String, tc.profile),
+						"config_file":        tftypes.NewValue(tftypes.String, configFileValue),
+						"credential_process": tftypes.NewValue(tftypes.String, tc.credentialProcess),
+						"host":               tftypes.NewValue(tftypes.String, nil),
+						"tenant_key":         tftypes.NewValue(tftypes.String, nil),
+						"application_key":    tftypes.NewValue(tftypes.String, nil),
+						"expires_at":         tftypes.NewValue(tftypes.String, nil),
+					}),
+				},
+			}
+
+			resp := ephemeral.OpenResponse{
+				Result: tfsdk.EphemeralResultData{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaType, nil),
+				},
+			}
+			newPrivateData(&resp.Private)
+
+			e.Open(ctx, req, &resp)
+			if tc.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result credentialsEphemeralModel
+			if diags := resp.Result.Get(ctx, &result); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			for _, v := range []struct {
+				name             string
+				actual, expected types.String
+			}{
+				{"host", result.Host, tc.expected.Host},
+				{"tenant_key", result.TenantKey, tc.expected.TenantKey},
+				{"application_key", result.ApplicationKey, tc.expected.ApplicationKey},
+				{"expires_at", result.ExpiresAt, tc.expected.ExpiresAt},
+			} {
+				if !v.actual.Equal(v.expected) {
+					t.Errorf("expected %s %s, got %s", v.name, v.expected, v.actual)
+				}
+			}
+
+			source, diags := getCredentialsSource(ctx, resp.Private)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if renew := source != nil && !resp.RenewAt.IsZero(); renew != tc.renew {
+				t.Errorf("expected renew %t, got source %+v and renew at %s", tc.renew, source, resp.RenewAt)
+			}
+		})
+	}
+}
+
+func TestCredentialsEphemeralResourceRenew(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	testCases := map[string]struct {
+		output  string
+		renewAt time.Time
+		wantErr bool
+	}{
+		"extended": {
+			output:  `{"tenant_key": "tenant", "application_key": "application", "expires_at": "` + expiresAt.Format(time.RFC3339) + `"}`,
+			renewAt: expiresAt.Add(-credentialProcessExpiryWindow),
+		},
+		"changed": {
+			output:  `{"tenant_key": "tenant", "application_key": "rotated", "expires_at": "` + expiresAt.Format(time.RFC3339) + `"}`,
+			wantErr: true,
+		},
+		"failing": {
+			output:  `not json`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "credentials.json")
+			if err := os.WriteFile(output, []byte(tc.output), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			req := ephemeral.RenewRequest{}
+			newPrivateData(&req.Private)
+			if diags := setCredentialsSource(ctx, req.Private, credentialsSource{
+				CredentialProcess: "cat " + output,
+				Fingerprint:       credentialsFingerprint(longship.Credentials{TenantKey: "tenant", ApplicationKey: "application"}),
+			}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := ephemeral.RenewResponse{Private: req.Private}
+
+			(&credentialsEphemeralResource{}).Renew(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() != tc.wantErr {
+				t.Fatalf("expected error %t, got diagnostics: %v", tc.wantErr, resp.Diagnostics)
+			}
+
+			if !resp.RenewAt.Equal(tc.renewAt) {
+				t.Errorf("expected renew at %s, got %s", tc.renewAt, resp.RenewAt)
+			}
+		})
+	}
+}
+
+// newPrivateData initializes the private data of an ephemeral resource
+// response, whose type is internal to the framework.
+func newPrivateData(private any) {
+	v := reflect.ValueOf(private).Elem()
+	v.Set(reflect.New(v.Type().Elem()))
+}
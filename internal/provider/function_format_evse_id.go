@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var _ function.Function = &formatEvseIDFunction{}
+
+func NewFormatEvseIDFunction() function.Function {
+	return &formatEvseIDFunction{}
+}
+
+type formatEvseIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *formatEvseIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_evse_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *formatEvseIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an EVSE ID from its parts",
+		MarkdownDescription: "Builds an EVSE ID as defined by ISO 15118-2 and eMI3 with `*` separators, e.g. `NL*LSP*E12345*1`. The parts are uppercased.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "country_code",
+				MarkdownDescription: "The ISO 3166-1 alpha-2 code of the country of the operator, e.g. `NL`.",
+			},
+			function.StringParameter{
+				Name:                "operator_id",
+				MarkdownDescription: "The three character ID of the charge point operator, e.g. `LSP`.",
+			},
+			function.StringParameter{
+				Name:                "power_outlet_id",
+				MarkdownDescription: "The ID of the EVSE within the operator without the leading `E`, e.g. `12345*1`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the EVSE ID.
+func (f *formatEvseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var countryCode, operatorID, powerOutletID string
+
+	resp.Error = req.Arguments.Get(ctx, &countryCode, &operatorID, &powerOutletID)
+	if resp.Error != nil {
+		return
+	}
+
+	id, err := longship.FormatEvseID(countryCode, operatorID, powerOutletID)
+	if err != nil {
+		resp.Error = function.NewFuncError("Invalid EVSE ID parts: " + err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFormatEvseIDFunction(t *testing.T) {
+	testCases := map[string]struct {
+		countryCode   string
+		operatorID    string
+		powerOutletID string
+		expected      string
+		err           string
+	}{
+		"valid": {
+			countryCode:   "nl",
+			operatorID:    "LSP",
+			powerOutletID: "12345*1",
+			expected:      "NL*LSP*E12345*1",
+		},
+		"invalid country code": {
+			countryCode:   "NLD",
+			operatorID:    "LSP",
+			powerOutletID: "12345",
+			err:           "country code must be two letters",
+		},
+		"empty power outlet id": {
+			countryCode: "NL",
+			operatorID:  "LSP",
+			err:         "power outlet ID must be 1 to 31 letters",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewFormatEvseIDFunction(),
+				types.StringValue(tc.countryCode),
+				types.StringValue(tc.operatorID),
+				types.StringValue(tc.powerOutletID),
+			)
+			if tc.err != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, funcErr)
+				}
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+
+			if !result.Equal(types.StringValue(tc.expected)) {
+				t.Errorf("expected %q, got %s", tc.expected, result)
+			}
+		})
+	}
+}
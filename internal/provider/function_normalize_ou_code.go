@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var _ function.Function = &normalizeOUCodeFunction{}
+
+func NewNormalizeOUCodeFunction() function.Function {
+	return &normalizeOUCodeFunction{}
+}
+
+type normalizeOUCodeFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeOUCodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_ou_code"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeOUCodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize an organizational unit code",
+		MarkdownDescription: "Normalizes the code of an organizational unit, e.g. for the `ou_code` of a webhook, by trimming surrounding whitespace. The case is kept. Fails when the code is empty or contains whitespace.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ou_code",
+				MarkdownDescription: "The organizational unit code to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the OU code.
+func (f *normalizeOUCodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var ouCode string
+
+	resp.Error = req.Arguments.Get(ctx, &ouCode)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := longship.NormalizeOUCode(ouCode)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeOUCodeFunction(t *testing.T) {
+	testCases := map[string]struct {
+		ouCode   string
+		expected string
+		err      string
+	}{
+		"already normalized": {
+			ouCode:   "0000",
+			expected: "0000",
+		},
+		"surrounding whitespace": {
+			ouCode:   "  nl-lsp-01\n",
+			expected: "nl-lsp-01",
+		},
+		"empty": {
+			ouCode: "   ",
+			err:    "must not be empty",
+		},
+		"inner whitespace": {
+			ouCode: "NL LSP",
+			err:    "must not contain whitespace",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewNormalizeOUCodeFunction(), types.StringValue(tc.ouCode))
+			if tc.err != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, funcErr)
+				}
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+
+			if !result.Equal(types.StringValue(tc.expected)) {
+				t.Errorf("expected %q, got %s", tc.expected, result)
+			}
+		})
+	}
+}
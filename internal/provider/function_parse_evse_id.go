@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var _ function.Function = &parseEvseIDFunction{}
+
+// evseIDAttributeTypes are the attributes of the object returned by
+// parse_evse_id.
+var evseIDAttributeTypes = map[string]attr.Type{
+	"evse_id":         types.StringType,
+	"country_code":    types.StringType,
+	"operator_id":     types.StringType,
+	"power_outlet_id": types.StringType,
+}
+
+func NewParseEvseIDFunction() function.Function {
+	return &parseEvseIDFunction{}
+}
+
+type parseEvseIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseEvseIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_evse_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseEvseIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an EVSE ID into its parts",
+		MarkdownDescription: "Parses an EVSE ID as defined by ISO 15118-2 and eMI3, with or without `*` separators, e.g. `NL*LSP*E12345*1` or `NLLSPE12345`. " +
+			"Returns an object with the normalized `evse_id` including separators, the `country_code`, the `operator_id` and the `power_outlet_id`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "evse_id",
+				MarkdownDescription: "The EVSE ID to parse, e.g. the `evse_id` of an EVSE of the `longship_chargepoints` data source.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: evseIDAttributeTypes,
+		},
+	}
+}
+
+// Run parses the EVSE ID.
+func (f *parseEvseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	evseID, err := longship.ParseEvseID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(evseIDAttributeTypes, map[string]attr.Value{
+		"evse_id":         types.StringValue(evseID.String()),
+		"country_code":    types.StringValue(evseID.CountryCode),
+		"operator_id":     types.StringValue(evseID.OperatorID),
+		"power_outlet_id": types.StringValue(evseID.PowerOutletID),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
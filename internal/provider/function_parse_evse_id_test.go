@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseEvseIDFunction(t *testing.T) {
+	testCases := map[string]struct {
+		evseID   string
+		expected map[string]attr.Value
+		err      string
+	}{
+		"with separators": {
+			evseID: "NL*LSP*E12345*1",
+			expected: map[string]attr.Value{
+				"evse_id":         types.StringValue("NL*LSP*E12345*1"),
+				"country_code":    types.StringValue("NL"),
+				"operator_id":     types.StringValue("LSP"),
+				"power_outlet_id": types.StringValue("12345*1"),
+			},
+		},
+		"without separators": {
+			evseID: "nllspe12345",
+			expected: map[string]attr.Value{
+				"evse_id":         types.StringValue("NL*LSP*E12345"),
+				"country_code":    types.StringValue("NL"),
+				"operator_id":     types.StringValue("LSP"),
+				"power_outlet_id": types.StringValue("12345"),
+			},
+		},
+		"invalid operator id": {
+			evseID: "NL*L-P*E12345",
+			err:    "operator ID must be three letters or digits",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewParseEvseIDFunction(), types.StringValue(tc.evseID))
+			if tc.err != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, funcErr)
+				}
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+
+			expected := types.ObjectValueMust(evseIDAttributeTypes, tc.expected)
+			if !result.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+}
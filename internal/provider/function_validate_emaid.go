@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var _ function.Function = &validateEMAIDFunction{}
+
+func NewValidateEMAIDFunction() function.Function {
+	return &validateEMAIDFunction{}
+}
+
+type validateEMAIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateEMAIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_emaid"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateEMAIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate an eMAID contract ID",
+		MarkdownDescription: "Validates the structure of an e-mobility account identifier (eMAID, contract ID) as defined by ISO 15118-2 and eMI3, e.g. `NL-TNM-012345678-9`, with or without `-` separators. " +
+			"Returns `true` when the eMAID is valid and fails with an error describing the problem otherwise, so wrap it in `can()` to test a value. The check character is not verified.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "emaid",
+				MarkdownDescription: "The eMAID to validate.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run validates the eMAID.
+func (f *validateEMAIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var emaid string
+
+	resp.Error = req.Arguments.Get(ctx, &emaid)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := longship.ValidateEMAID(emaid); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, true)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateEMAIDFunction(t *testing.T) {
+	testCases := map[string]struct {
+		emaid string
+		err   string
+	}{
+		"valid": {
+			emaid: "NL-TNM-012345678-9",
+		},
+		"invalid": {
+			emaid: "NL-TNM-0123",
+			err:   "expected 14 or 15 letters or digits",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewValidateEMAIDFunction(), types.StringValue(tc.emaid))
+			if tc.err != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, funcErr)
+				}
+				if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+					t.Errorf("expected error for the first argument, got %v", funcErr.FunctionArgument)
+				}
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+
+			if !result.Equal(types.BoolValue(true)) {
+				t.Errorf("expected true, got %s", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var _ function.Function = &validateIBANFunction{}
+
+func NewValidateIBANFunction() function.Function {
+	return &validateIBANFunction{}
+}
+
+type validateIBANFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateIBANFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_iban"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateIBANFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate an IBAN",
+		MarkdownDescription: "Validates an International Bank Account Number, such as the IBAN of the financial details of an organizational unit, including its check digits. Spaces are ignored. " +
+			"Returns `true` when the IBAN is valid and fails with an error describing the problem otherwise, so wrap it in `can()` to test a value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "iban",
+				MarkdownDescription: "The IBAN to validate, e.g. `NL91 ABNA 0417 1643 00`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run validates the IBAN.
+func (f *validateIBANFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var iban string
+
+	resp.Error = req.Arguments.Get(ctx, &iban)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := longship.ValidateIBAN(iban); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, true)
+}
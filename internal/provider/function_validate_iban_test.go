@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateIBANFunction(t *testing.T) {
+	testCases := map[string]struct {
+		iban string
+		err  string
+	}{
+		"valid": {
+			iban: "NL91 ABNA 0417 1643 00",
+		},
+		"invalid": {
+			iban: "NL92ABNA0417164300",
+			err:  "check digits do not match",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewValidateIBANFunction(), types.StringValue(tc.iban))
+			if tc.err != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, funcErr)
+				}
+				if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+					t.Errorf("expected error for the first argument, got %v", funcErr.FunctionArgument)
+				}
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+
+			if !result.Equal(types.BoolValue(true)) {
+				t.Errorf("expected true, got %s", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+const (
+	// httpLogSubsystem is the tflog subsystem used for HTTP wire logging. Its
+	// level is set with the TF_LOG_PROVIDER_LONGSHIP_HTTP environment variable.
+	httpLogSubsystem = "http"
+
+	// defaultHTTPLogMaxBodyBytes is the default size at which logged request
+	// and response bodies are truncated.
+	defaultHTTPLogMaxBodyBytes = 4096
+
+	redacted = "***"
+)
+
+// sensitiveHTTPHeaders are masked when logging requests.
+var sensitiveHTTPHeaders = []string{
+	"Ocp-Apim-Subscription-Key",
+	"X-Api-Key",
+	"Authorization",
+}
+
+// loggingTransport logs every request and response at TRACE level through
+// the http tflog subsystem, masking credentials and webhook header values.
+type loggingTransport struct {
+	transport    http.RoundTripper
+	maxBodyBytes int
+}
+
+func newLoggingTransport(transport http.RoundTripper, maxBodyBytes int) *loggingTransport {
+	return &loggingTransport{
+		transport:    transport,
+		maxBodyBytes: maxBodyBytes,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// The subsystem is created from the context of the request, so that it
+	// carries the fields and masks of the RPC which sent it.
+	ctx := tflog.NewSubsystem(req.Context(), httpLogSubsystem,
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER_LONGSHIP", "HTTP"),
+		tflog.WithRootFields(),
+	)
+	ctx = tflog.SubsystemSetField(ctx, httpLogSubsystem, "longship_correlation_id", req.Header.Get(longship.CorrelationIDHeader))
+
+	// A RoundTripper must not modify the request, so the body read for
+	// logging is replaced on a copy.
+	req = req.Clone(req.Context())
+
+	reqBody, err := t.readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "Sending HTTP request", map[string]any{
+		"http_method":      req.Method,
+		"http_url":         req.URL.String(),
+		"http_req_headers": redactHeaders(req.Header),
+		"http_req_body":    t.formatBody(reqBody),
+	})
+
+	start := time.Now()
+	res, err := t.transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemTrace(ctx, httpLogSubsystem, "HTTP request failed", map[string]any{
+			"http_method":      req.Method,
+			"http_url":         req.URL.String(),
+			"http_duration_ms": duration.Milliseconds(),
+			"error":            err.Error(),
+		})
+		return nil, err
+	}
+
+	resBody, err := t.readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "Received HTTP response", map[string]any{
+		"http_method":      req.Method,
+		"http_url":         req.URL.String(),
+		"http_status_code": res.StatusCode,
+		"http_duration_ms": duration.Milliseconds(),
+		"http_res_body":    t.formatBody(resBody),
+	})
+
+	return res, nil
+}
+
+// readBody reads body and replaces it with an equivalent reader, so that it
+// can still be consumed after logging.
+func (t *loggingTransport) readBody(body *io.ReadCloser) ([]byte, error) {
+
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(b))
+
+	return b, nil
+}
+
+// formatBody redacts and truncates body for logging.
+func (t *loggingTransport) formatBody(body []byte) string {
+
+	b := redactBody(body)
+	if t.maxBodyBytes > 0 && len(b) > t.maxBodyBytes {
+		return fmt.Sprintf("%s... (truncated %d bytes)", b[:t.maxBodyBytes], len(b)-t.maxBodyBytes)
+	}
+
+	return string(b)
+}
+
+// redactHeaders returns a copy of headers with credential values masked.
+func redactHeaders(headers http.Header) map[string]string {
+
+	result := map[string]string{}
+	for name := range headers {
+		result[name] = headers.Get(name)
+	}
+
+	for _, name := range sensitiveHTTPHeaders {
+		if headers.Get(name) != "" {
+			result[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+
+	return result
+}
+
+// redactBody masks the values of webhook headers in a JSON body. Bodies which
+// are not JSON are returned unchanged.
+func redactBody(body []byte) []byte {
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+
+	redactWebhookHeaders(v)
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return b
+}
+
+func redactWebhookHeaders(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			redactWebhookHeaders(item)
+		}
+	case map[string]any:
+		for key, value := range v {
+			headers, ok := value.([]any)
+			if key != "headers" || !ok {
+				redactWebhookHeaders(value)
+				continue
+			}
+
+			for _, header := range headers {
+				if h, ok := header.(map[string]any); ok {
+					if _, ok := h["value"]; ok {
+						h["value"] = redacted
+					}
+				}
+			}
+		}
+	}
+}
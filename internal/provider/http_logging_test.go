@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected string
+	}{
+		"webhook": {
+			body:     `{"name":"test","headers":[{"name":"Authorization","value":"Bearer secret"}]}`,
+			expected: `{"headers":[{"name":"Authorization","value":"***"}],"name":"test"}`,
+		},
+		"webhook list": {
+			body:     `[{"id":"1","headers":[{"name":"hello","value":"world"}]}]`,
+			expected: `[{"headers":[{"name":"hello","value":"***"}],"id":"1"}]`,
+		},
+		"not json": {
+			body:     `Access denied due to invalid subscription key.`,
+			expected: `Access denied due to invalid subscription key.`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := string(redactBody([]byte(tc.body))); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Ocp-Apim-Subscription-Key", "tenant")
+	headers.Set("x-api-key", "application")
+	headers.Set("Content-Type", "application/json")
+
+	got := redactHeaders(headers)
+
+	for _, name := range []string{"Ocp-Apim-Subscription-Key", "X-Api-Key"} {
+		if got[name] != redacted {
+			t.Errorf("expected header %s to be redacted, got %q", name, got[name])
+		}
+	}
+
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("expected Content-Type header to be kept, got %q", got["Content-Type"])
+	}
+}
+
+func TestLoggingTransportFormatBody(t *testing.T) {
+	transport := newLoggingTransport(http.DefaultTransport, 10)
+
+	got := transport.formatBody([]byte(strings.Repeat("a", 15)))
+	if got != "aaaaaaaaaa... (truncated 5 bytes)" {
+		t.Errorf("unexpected truncated body: %s", got)
+	}
+}
+
+func TestLoggingTransportRoundTrip(t *testing.T) {
+	testCases := map[string]struct {
+		level string
+	}{
+		"trace":      {level: "TRACE"},
+		"debug":      {level: "DEBUG"},
+		"not logged": {level: ""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TF_LOG_PROVIDER_LONGSHIP_HTTP", tc.level)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			transport := newLoggingTransport(http.DefaultTransport, 0)
+
+			body := io.NopCloser(strings.NewReader(`{"name":"test"}`))
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if req.Body != body {
+				t.Error("expected the request body not to be replaced")
+			}
+
+			got, _ := io.ReadAll(res.Body)
+			if string(got) != `{"name":"test"}` {
+				t.Errorf("expected request body to be sent, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// organizationalUnitCodes lazily retrieves the organizational unit codes of
+// the tenant once per provider run.
+type organizationalUnitCodes struct {
+	client *longship.Client
+
+	mu    sync.Mutex
+	codes []string
+}
+
+// Get returns the organizational unit codes of the tenant, calling the API
+// until it succeeds once. Failures are not cached, so that a later call can
+// retry them.
+func (o *organizationalUnitCodes) Get(ctx context.Context) ([]string, error) {
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.codes != nil {
+		return o.codes, nil
+	}
+
+	// The codes are shared with other callers, which must not fail because
+	// the context of the caller fetching them is cancelled.
+	organizationalUnits, err := o.client.OrganizationalUnits.List(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(organizationalUnits))
+	for _, ou := range organizationalUnits {
+		codes = append(codes, ou.Code)
+	}
+	o.codes = codes
+
+	return o.codes, nil
+}
+
+// unknownOUCodeDetail describes that code does not exist in the tenant with
+// codes, suggesting similar codes.
+func unknownOUCodeDetail(code string, codes []string) string {
+
+	detail := fmt.Sprintf("The organizational unit code %q does not exist in this Longship tenant.", code)
+	if suggestions := suggestOUCodes(code, codes); len(suggestions) > 0 {
+		quoted := []string{}
+		for _, suggestion := range suggestions {
+			quoted = append(quoted, fmt.Sprintf("%q", suggestion))
+		}
+		detail += fmt.Sprintf(" Did you mean %s?", strings.Join(quoted, " or "))
+	}
+
+	return detail
+}
+
+// suggestOUCodes returns up to three codes which closely resemble code,
+// ordered from closest to furthest.
+func suggestOUCodes(code string, codes []string) []string {
+
+	type candidate struct {
+		code     string
+		distance int
+	}
+
+	maxDistance := len(code) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	candidates := []candidate{}
+	for _, c := range codes {
+		distance := levenshtein(strings.ToLower(code), strings.ToLower(c))
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{code: c, distance: distance})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].code < candidates[j].code
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, candidates[i].code)
+	}
+
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
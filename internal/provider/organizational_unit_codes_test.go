@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestOrganizationalUnitCodesGet(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"code": "0000"}, {"code": "0001"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := &organizationalUnitCodes{client: client}
+
+	if _, err := codes.Get(context.Background()); err == nil {
+		t.Fatal("expected error on first call, got none")
+	}
+
+	// The codes are retrieved independently of the context of the caller.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := codes.Get(ctx)
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+
+	expected := []string{"0000", "0001"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if _, err := codes.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected codes to be cached after success, got %d calls", calls)
+	}
+}
+
+func TestSuggestOUCodes(t *testing.T) {
+	codes := []string{"0000", "0001", "0100", "NL-AMS", "NL-RTM", "DE-BER"}
+
+	testCases := map[string]struct {
+		code     string
+		expected []string
+	}{
+		"single typo": {
+			code:     "NL-AMX",
+			expected: []string{"NL-AMS"},
+		},
+		"case mismatch": {
+			code:     "nl-ams",
+			expected: []string{"NL-AMS"},
+		},
+		"several close codes": {
+			code:     "0002",
+			expected: []string{"0000", "0001"},
+		},
+		"no close codes": {
+			code:     "FR-PAR",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := suggestOUCodes(tc.code, codes)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
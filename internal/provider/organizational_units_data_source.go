@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -19,7 +21,7 @@ var (
 
 // OrganizationalUnitsDataSource is the data source implementation.
 type OrganizationalUnitsDataSource struct {
-	client *Client
+	client *longship.Client
 }
 
 type OrganizationalUnitsDataSourceModel struct {
@@ -27,30 +29,30 @@ type OrganizationalUnitsDataSourceModel struct {
 }
 
 type OrganizationalUnitDataSourceModel struct {
-	ID                        types.String                    `tfsdk:"id"`
-	ParentID                  types.String                    `tfsdk:"parent_id"`
-	Name                      types.String                    `tfsdk:"name"`
-	Code                      types.String                    `tfsdk:"code"`
-	ExternalReference         types.String                    `tfsdk:"external_reference"`
-	GridOwnerReference        types.String                    `tfsdk:"grid_owner_reference"`
-	TenantReference           types.String                    `tfsdk:"tenant_reference"`
-	CustomerReference         types.String                    `tfsdk:"customer_reference"`
-	Address                   types.String                    `tfsdk:"address"`
-	State                     types.String                    `tfsdk:"state"`
-	Country                   types.String                    `tfsdk:"country"`
-	City                      types.String                    `tfsdk:"city"`
-	HouseNumber               types.String                    `tfsdk:"house_number"`
-	PostalCode                types.String                    `tfsdk:"postal_code"`
-	HotlinePhoneNumber        types.String                    `tfsdk:"hotline_phone_number"`
-	CompanyEmail              types.String                    `tfsdk:"company_email"`
-	PrimaryContactPerson      types.String                    `tfsdk:"primary_contact_person"`
-	PrimaryContactPersonEmail types.String                    `tfsdk:"primary_contact_person_email"`
-	DirectPaymentProfileId    types.String                    `tfsdk:"direct_payment_profile_id"`
-	MspOuID                   types.String                    `tfsdk:"msp_ou_id"`
-	MspOuName                 types.String                    `tfsdk:"msp_ou_name"`
-	MspOuCode                 types.String                    `tfsdk:"msp_ou_code"`
-	MspExternalID             types.String                    `tfsdk:"msp_external_id"`
-	FinancialDetails          FinancialDetailsDataSourceModel `tfsdk:"financial_details"`
+	ID                        types.String                     `tfsdk:"id"`
+	ParentID                  types.String                     `tfsdk:"parent_id"`
+	Name                      types.String                     `tfsdk:"name"`
+	Code                      types.String                     `tfsdk:"code"`
+	ExternalReference         types.String                     `tfsdk:"external_reference"`
+	GridOwnerReference        types.String                     `tfsdk:"grid_owner_reference"`
+	TenantReference           types.String                     `tfsdk:"tenant_reference"`
+	CustomerReference         types.String                     `tfsdk:"customer_reference"`
+	Address                   types.String                     `tfsdk:"address"`
+	State                     types.String                     `tfsdk:"state"`
+	Country                   types.String                     `tfsdk:"country"`
+	City                      types.String                     `tfsdk:"city"`
+	HouseNumber               types.String                     `tfsdk:"house_number"`
+	PostalCode                types.String                     `tfsdk:"postal_code"`
+	HotlinePhoneNumber        types.String                     `tfsdk:"hotline_phone_number"`
+	CompanyEmail              types.String                     `tfsdk:"company_email"`
+	PrimaryContactPerson      types.String                     `tfsdk:"primary_contact_person"`
+	PrimaryContactPersonEmail types.String                     `tfsdk:"primary_contact_person_email"`
+	DirectPaymentProfileId    types.String                     `tfsdk:"direct_payment_profile_id"`
+	MspOuID                   types.String                     `tfsdk:"msp_ou_id"`
+	MspOuName                 types.String                     `tfsdk:"msp_ou_name"`
+	MspOuCode                 types.String                     `tfsdk:"msp_ou_code"`
+	MspExternalID             types.String                     `tfsdk:"msp_external_id"`
+	FinancialDetails          *FinancialDetailsDataSourceModel `tfsdk:"financial_details"`
 }
 
 type FinancialDetailsDataSourceModel struct {
@@ -69,17 +71,17 @@ func (d *OrganizationalUnitsDataSource) Configure(_ context.Context, req datasou
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 // Metadata returns the data source type name.
@@ -180,9 +182,12 @@ func (d *OrganizationalUnitsDataSource) Schema(_ context.Context, _ datasource.S
 
 func (d *OrganizationalUnitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	ctx, span := startSpan(ctx, "longship_organizational_units", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state OrganizationalUnitsDataSourceModel
 
-	organizationalUnits, err := d.client.GetOrganizationalUnits()
+	organizationalUnits, err := d.client.OrganizationalUnits.List(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Longship Organizational Units",
@@ -191,46 +196,11 @@ func (d *OrganizationalUnitsDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	tflog.Warn(ctx, fmt.Sprintf("Reading all organizational units: %+v", organizationalUnits))
+	tflog.Info(ctx, fmt.Sprintf("Read %d organizational units", len(organizationalUnits)))
 
 	// Map response body to model
 	for _, ou := range organizationalUnits {
-
-		tflog.Warn(ctx, fmt.Sprintf("Organizational Unit: %+v", ou))
-
-		ouState := OrganizationalUnitDataSourceModel{
-			ID:                        types.StringValue(ou.ID),
-			ParentID:                  types.StringValue(ou.ParentID),
-			Name:                      types.StringValue(ou.Name),
-			Code:                      types.StringValue(ou.Code),
-			ExternalReference:         types.StringValue(ou.ExternalReference),
-			GridOwnerReference:        types.StringValue(ou.GridOwnerReference),
-			TenantReference:           types.StringValue(ou.TenantReference),
-			CustomerReference:         types.StringValue(ou.CustomerReference),
-			Address:                   types.StringValue(ou.Address),
-			State:                     types.StringValue(ou.State),
-			Country:                   types.StringValue(ou.Country),
-			City:                      types.StringValue(ou.City),
-			HouseNumber:               types.StringValue(ou.HouseNumber),
-			PostalCode:                types.StringValue(ou.PostalCode),
-			HotlinePhoneNumber:        types.StringValue(ou.HotlinePhoneNumber),
-			CompanyEmail:              types.StringValue(ou.CompanyEmail),
-			PrimaryContactPerson:      types.StringValue(ou.PrimaryContactPerson),
-			PrimaryContactPersonEmail: types.StringValue(ou.PrimaryContactPersonEmail),
-			DirectPaymentProfileId:    types.StringValue(ou.DirectPaymentProfileId),
-			MspOuID:                   types.StringValue(ou.MspOuID),
-			MspOuName:                 types.StringValue(ou.MspOuName),
-			MspOuCode:                 types.StringValue(ou.MspOuCode),
-			MspExternalID:             types.StringValue(ou.MspExternalID),
-
-			FinancialDetails: FinancialDetailsDataSourceModel{
-				BeneficiaryName: types.StringValue(ou.FinancialDetails.BeneficiaryName),
-				IBAN:            types.StringValue(ou.FinancialDetails.IBAN),
-				BIC:             types.StringValue(ou.FinancialDetails.BIC),
-			},
-		}
-
-		state.OrganizationalUnits = append(state.OrganizationalUnits, ouState)
+		state.OrganizationalUnits = append(state.OrganizationalUnits, flattenOrganizationalUnit(ou))
 	}
 
 	// Set state
@@ -240,3 +210,44 @@ func (d *OrganizationalUnitsDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 }
+
+// flattenOrganizationalUnit maps ou to its data source model, with null values
+// for fields absent from the API response.
+func flattenOrganizationalUnit(ou longship.OrganizationalUnit) OrganizationalUnitDataSourceModel {
+
+	ouState := OrganizationalUnitDataSourceModel{
+		ID:                        types.StringValue(ou.ID),
+		ParentID:                  types.StringPointerValue(ou.ParentID),
+		Name:                      types.StringValue(ou.Name),
+		Code:                      types.StringValue(ou.Code),
+		ExternalReference:         types.StringPointerValue(ou.ExternalReference),
+		GridOwnerReference:        types.StringPointerValue(ou.GridOwnerReference),
+		TenantReference:           types.StringPointerValue(ou.TenantReference),
+		CustomerReference:         types.StringPointerValue(ou.CustomerReference),
+		Address:                   types.StringPointerValue(ou.Address),
+		State:                     types.StringPointerValue(ou.State),
+		Country:                   types.StringPointerValue(ou.Country),
+		City:                      types.StringPointerValue(ou.City),
+		HouseNumber:               types.StringPointerValue(ou.HouseNumber),
+		PostalCode:                types.StringPointerValue(ou.PostalCode),
+		HotlinePhoneNumber:        types.StringPointerValue(ou.HotlinePhoneNumber),
+		CompanyEmail:              types.StringPointerValue(ou.CompanyEmail),
+		PrimaryContactPerson:      types.StringPointerValue(ou.PrimaryContactPerson),
+		PrimaryContactPersonEmail: types.StringPointerValue(ou.PrimaryContactPersonEmail),
+		DirectPaymentProfileId:    types.StringPointerValue(ou.DirectPaymentProfileId),
+		MspOuID:                   types.StringPointerValue(ou.MspOuID),
+		MspOuName:                 types.StringPointerValue(ou.MspOuName),
+		MspOuCode:                 types.StringPointerValue(ou.MspOuCode),
+		MspExternalID:             types.StringPointerValue(ou.MspExternalID),
+	}
+
+	if ou.FinancialDetails != nil {
+		ouState.FinancialDetails = &FinancialDetailsDataSourceModel{
+			BeneficiaryName: types.StringPointerValue(ou.FinancialDetails.BeneficiaryName),
+			IBAN:            types.StringPointerValue(ou.FinancialDetails.IBAN),
+			BIC:             types.StringPointerValue(ou.FinancialDetails.BIC),
+		}
+	}
+
+	return ouState
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestFlattenOrganizationalUnit(t *testing.T) {
+	testCases := map[string]struct {
+		fixture          string
+		parentID         types.String
+		mspOuID          types.String
+		city             types.String
+		financialDetails *FinancialDetailsDataSourceModel
+	}{
+		"all fields": {
+			fixture: `{"id": "2", "parentId": "1", "name": "Child", "code": "0001", "msp_ou_id": "3", "city": "Utrecht",
+				"financialDetails": {"beneficiaryName": "Longship", "iban": "NL91ABNA0417164300", "bic": "ABNANL2A"}}`,
+			parentID: types.StringValue("1"),
+			mspOuID:  types.StringValue("3"),
+			city:     types.StringValue("Utrecht"),
+			financialDetails: &FinancialDetailsDataSourceModel{
+				BeneficiaryName: types.StringValue("Longship"),
+				IBAN:            types.StringValue("NL91ABNA0417164300"),
+				BIC:             types.StringValue("ABNANL2A"),
+			},
+		},
+		"null fields": {
+			fixture:          `{"id": "1", "parentId": null, "name": "Root", "code": "0000", "msp_ou_id": null, "city": null, "financialDetails": null}`,
+			parentID:         types.StringNull(),
+			mspOuID:          types.StringNull(),
+			city:             types.StringNull(),
+			financialDetails: nil,
+		},
+		"absent fields": {
+			fixture:  `{"id": "1", "name": "Root", "code": "0000", "financialDetails": {"iban": "NL91ABNA0417164300"}}`,
+			parentID: types.StringNull(),
+			mspOuID:  types.StringNull(),
+			city:     types.StringNull(),
+			financialDetails: &FinancialDetailsDataSourceModel{
+				BeneficiaryName: types.StringNull(),
+				IBAN:            types.StringValue("NL91ABNA0417164300"),
+				BIC:             types.StringNull(),
+			},
+		},
+		"empty strings": {
+			fixture:  `{"id": "1", "parentId": "", "name": "Root", "code": "0000", "msp_ou_id": "", "city": ""}`,
+			parentID: types.StringValue(""),
+			mspOuID:  types.StringValue(""),
+			city:     types.StringValue(""),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var ou longship.OrganizationalUnit
+			if err := json.Unmarshal([]byte(tc.fixture), &ou); err != nil {
+				t.Fatal(err)
+			}
+
+			state := flattenOrganizationalUnit(ou)
+
+			if !state.ParentID.Equal(tc.parentID) {
+				t.Errorf("expected parent_id %s, got %s", tc.parentID, state.ParentID)
+			}
+
+			if !state.MspOuID.Equal(tc.mspOuID) {
+				t.Errorf("expected msp_ou_id %s, got %s", tc.mspOuID, state.MspOuID)
+			}
+
+			if !state.City.Equal(tc.city) {
+				t.Errorf("expected city %s, got %s", tc.city, state.City)
+			}
+
+			if (state.FinancialDetails == nil) != (tc.financialDetails == nil) {
+				t.Fatalf("expected financial_details %+v, got %+v", tc.financialDetails, state.FinancialDetails)
+			}
+
+			if tc.financialDetails != nil && *state.FinancialDetails != *tc.financialDetails {
+				t.Errorf("expected financial_details %+v, got %+v", *tc.financialDetails, *state.FinancialDetails)
+			}
+		})
+	}
+}
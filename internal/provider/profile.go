@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfile is the profile used when none is selected.
+const defaultProfile = "default"
+
+// configProfile holds the settings of a named profile in the Longship config
+// file.
+type configProfile struct {
+	Host           string
+	TenantKey      string
+	ApplicationKey string
+
+	// CredentialProcess is a command printing the credentials, used instead
+	// of TenantKey and ApplicationKey.
+	CredentialProcess string
+}
+
+// useCredentialProcess overrides the credentials with a credential process.
+func (p *configProfile) useCredentialProcess(command string) {
+	p.CredentialProcess = command
+	p.TenantKey = ""
+	p.ApplicationKey = ""
+}
+
+// useTenantKey overrides the tenant key, and a credential process set by a
+// source of lower precedence.
+func (p *configProfile) useTenantKey(key string) {
+	p.TenantKey = key
+	p.CredentialProcess = ""
+}
+
+// useApplicationKey overrides the application key, and a credential process
+// set by a source of lower precedence.
+func (p *configProfile) useApplicationKey(key string) {
+	p.ApplicationKey = key
+	p.CredentialProcess = ""
+}
+
+// defaultConfigFilePath returns the path of the Longship config file in the
+// home directory of the current user.
+func defaultConfigFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".longship", "config"), nil
+}
+
+// profileNotFoundError is returned when an explicitly selected profile does
+// not exist in the config file.
+type profileNotFoundError struct {
+	name string
+	path string
+}
+
+func (e *profileNotFoundError) Error() string {
+	return fmt.Sprintf("profile %q not found in config file %s", e.name, e.path)
+}
+
+// loadConfigProfile reads the named profile from the config file at path.
+// When name is empty the default profile is used, and a missing file or
+// default profile is not an error.
+func loadConfigProfile(path, name string) (configProfile, error) {
+
+	explicit := name != ""
+	if !explicit {
+		name = defaultProfile
+	}
+
+	if path == "" {
+		p, err := defaultConfigFilePath()
+		if err != nil {
+			if explicit {
+				return configProfile{}, err
+			}
+			return configProfile{}, nil
+		}
+		path = p
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return configProfile{}, nil
+	}
+	if err != nil {
+		return configProfile{}, fmt.Errorf("unable to open config file: %w", err)
+	}
+	defer f.Close()
+
+	profiles, err := parseConfigFile(f)
+	if err != nil {
+		return configProfile{}, fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+
+	p, ok := profiles[name]
+	if !ok && explicit {
+		return configProfile{}, &profileNotFoundError{name: name, path: path}
+	}
+
+	return p, nil
+}
+
+// parseConfigFile parses an INI style config file with one section per
+// profile:
+//
+//	[default]
+//	host            = https://api.longship.io
+//	tenant_key      = ...
+//	application_key = ...
+//
+// Empty lines and lines starting with # or ; are ignored.
+func parseConfigFile(r io.Reader) (map[string]configProfile, error) {
+
+	profiles := map[string]configProfile{}
+	current := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if current == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			profiles[current] = profiles[current]
+			continue
+		}
+
+		if current == "" {
+			return nil, fmt.Errorf("line %d: setting outside of a profile", lineNumber)
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+
+		p := profiles[current]
+		switch key := strings.TrimSpace(key); key {
+		case "host":
+			p.Host = strings.TrimSpace(value)
+		case "tenant_key":
+			p.TenantKey = strings.TrimSpace(value)
+		case "application_key":
+			p.ApplicationKey = strings.TrimSpace(value)
+		case "credential_process":
+			p.CredentialProcess = strings.TrimSpace(value)
+		default:
+			return nil, fmt.Errorf("line %d: unknown setting %q", lineNumber, key)
+		}
+		profiles[current] = p
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
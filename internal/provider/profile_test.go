@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testConfigFile = `
+# Longship tenants
+[default]
+host            = https://dev.longship.example
+tenant_key      = dev-tenant
+application_key = dev-application
+
+[prod]
+host            = https://prod.longship.example
+tenant_key      = prod-tenant
+application_key = prod-application
+
+[vault]
+host               = https://vault.longship.example
+credential_process = vault-longship-credentials
+`
+
+func TestParseConfigFile(t *testing.T) {
+	profiles, err := parseConfigFile(strings.NewReader(testConfigFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := configProfile{
+		Host:           "https://prod.longship.example",
+		TenantKey:      "prod-tenant",
+		ApplicationKey: "prod-application",
+	}
+
+	if profiles["prod"] != expected {
+		t.Errorf("expected %+v, got %+v", expected, profiles["prod"])
+	}
+
+	for name, content := range map[string]string{
+		"setting outside profile": "host = https://example.com",
+		"unknown setting":         "[default]\nhostname = https://example.com",
+		"missing value":           "[default]\nhost",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseConfigFile(strings.NewReader(content)); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configFile, []byte(testConfigFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		config  longshipProviderModel
+		env     map[string]string
+		host    string
+		tenant  string
+		process string
+		wantErr bool
+	}{
+		"default profile": {
+			host:   "https://dev.longship.example",
+			tenant: "dev-tenant",
+		},
+		"profile attribute": {
+			config: longshipProviderModel{Profile: types.StringValue("prod")},
+			host:   "https://prod.longship.example",
+			tenant: "prod-tenant",
+		},
+		"profile environment variable": {
+			env:    map[string]string{"LONGSHIP_PROFILE": "prod"},
+			host:   "https://prod.longship.example",
+			tenant: "prod-tenant",
+		},
+		"profile attribute overrides environment variable": {
+			config: longshipProviderModel{Profile: types.StringValue("default")},
+			env:    map[string]string{"LONGSHIP_PROFILE": "prod"},
+			host:   "https://dev.longship.example",
+			tenant: "dev-tenant",
+		},
+		"environment variables override profile": {
+			config: longshipProviderModel{Profile: types.StringValue("prod")},
+			env:    map[string]string{"LONGSHIP_HOST": "https://env.longship.example"},
+			host:   "https://env.longship.example",
+			tenant: "prod-tenant",
+		},
+		"attributes override environment variables": {
+			config: longshipProviderModel{Host: types.StringValue("https://config.longship.example")},
+			env:    map[string]string{"LONGSHIP_HOST": "https://env.longship.example"},
+			host:   "https://config.longship.example",
+			tenant: "dev-tenant",
+		},
+		"credential process profile": {
+			config:  longshipProviderModel{Profile: types.StringValue("vault")},
+			host:    "https://vault.longship.example",
+			process: "vault-longship-credentials",
+		},
+		"credential process environment variable overrides profile keys": {
+			env:     map[string]string{"LONGSHIP_CREDENTIAL_PROCESS": "env-credentials"},
+			host:    "https://dev.longship.example",
+			process: "env-credentials",
+		},
+		"environment keys override profile credential process": {
+			config: longshipProviderModel{Profile: types.StringValue("vault")},
+			env:    map[string]string{"LONGSHIP_TENANT_KEY": "env-tenant"},
+			host:   "https://vault.longship.example",
+			tenant: "env-tenant",
+		},
+		"unknown profile": {
+			config:  longshipProviderModel{Profile: types.StringValue("acc")},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"LONGSHIP_HOST", "LONGSHIP_TENANT_KEY", "LONGSHIP_APPLICATION_KEY", "LONGSHIP_CREDENTIAL_PROCESS", "LONGSHIP_PROFILE"} {
+				t.Setenv(env, tc.env[env])
+			}
+			t.Setenv("LONGSHIP_CONFIG_FILE", configFile)
+
+			creds, err := resolveCredentials(tc.config)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if creds.Host != tc.host || creds.TenantKey != tc.tenant {
+				t.Errorf("expected host %q and tenant key %q, got %q and %q", tc.host, tc.tenant, creds.Host, creds.TenantKey)
+			}
+
+			if creds.CredentialProcess != tc.process {
+				t.Errorf("expected credential process %q, got %q", tc.process, creds.CredentialProcess)
+			}
+		})
+	}
+}
+
+func TestLoadConfigProfileMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "config")
+
+	if _, err := loadConfigProfile(missing, ""); err != nil {
+		t.Errorf("expected missing config file to be ignored for the default profile, got: %s", err)
+	}
+
+	if _, err := loadConfigProfile(missing, "prod"); err == nil {
+		t.Error("expected error for explicitly selected profile, got none")
+	}
+}
+
+func TestCredentialsErrorPath(t *testing.T) {
+	dir := t.TempDir()
+
+	configFile := filepath.Join(dir, "config")
+	if err := os.WriteFile(configFile, []byte(testConfigFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		config    longshipProviderModel
+		env       map[string]string
+		attribute string
+	}{
+		"unknown profile from configuration": {
+			config:    longshipProviderModel{Profile: types.StringValue("acc"), ConfigFile: types.StringValue(configFile)},
+			attribute: "profile",
+		},
+		"unknown profile from environment": {
+			config: longshipProviderModel{ConfigFile: types.StringValue(configFile)},
+			env:    map[string]string{"LONGSHIP_PROFILE": "acc"},
+		},
+		"missing config file from configuration": {
+			config:    longshipProviderModel{Profile: types.StringValue("prod"), ConfigFile: types.StringValue(filepath.Join(dir, "missing"))},
+			attribute: "config_file",
+		},
+		"missing config file from environment": {
+			config: longshipProviderModel{Profile: types.StringValue("prod")},
+			env:    map[string]string{"LONGSHIP_CONFIG_FILE": filepath.Join(dir, "missing")},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"LONGSHIP_PROFILE", "LONGSHIP_CONFIG_FILE"} {
+				t.Setenv(env, tc.env[env])
+			}
+
+			_, err := resolveCredentials(tc.config)
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			p, ok := credentialsErrorPath(tc.config, err)
+			if tc.attribute == "" {
+				if ok {
+					t.Errorf("expected no attribute, got %s", p)
+				}
+				return
+			}
+
+			if !ok || !p.Equal(path.Root(tc.attribute)) {
+				t.Errorf("expected attribute %s, got %s (%t)", tc.attribute, p, ok)
+			}
+		})
+	}
+}
@@ -2,20 +2,40 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
-
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &longshipProvider{}
+	_ provider.Provider                       = &longshipProvider{}
+	_ provider.ProviderWithEphemeralResources = &longshipProvider{}
+	_ provider.ProviderWithFunctions          = &longshipProvider{}
+	_ provider.ProviderWithListResources      = &longshipProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -42,9 +62,59 @@ func (p *longshipProvider) Metadata(_ context.Context, _ provider.MetadataReques
 }
 
 type longshipProviderModel struct {
-	Host           types.String `tfsdk:"host"`
-	TenantKey      types.String `tfsdk:"tenant_key"`
-	ApplicationKey types.String `tfsdk:"application_key"`
+	Host                types.String `tfsdk:"host"`
+	TenantKey           types.String `tfsdk:"tenant_key"`
+	ApplicationKey      types.String `tfsdk:"application_key"`
+	Profile             types.String `tfsdk:"profile"`
+	ConfigFile          types.String `tfsdk:"config_file"`
+	CredentialProcess   types.String `tfsdk:"credential_process"`
+	ValidateOUCodes     types.Bool   `tfsdk:"validate_ou_codes"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	CacheResponses        types.Bool    `tfsdk:"cache_responses"`
+	HTTPLogMaxBodyBytes   types.Int64   `tfsdk:"http_log_max_body_bytes"`
+
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	UserAgentSuffix    types.String `tfsdk:"user_agent_suffix"`
+
+	AllowedWebhookHosts types.Set `tfsdk:"allowed_webhook_hosts"`
+}
+
+const (
+	// defaultRequestTimeout is the timeout of requests to the Longship API.
+	defaultRequestTimeout = 30 * time.Second
+
+	// responseCacheTTL is how long responses of list endpoints are cached.
+	responseCacheTTL = 30 * time.Second
+)
+
+// providerData is made available to data sources and resources during their
+// Configure methods.
+type providerData struct {
+	client *longship.Client
+
+	// ouCodes caches the organizational unit codes of the tenant for
+	// plan-time validation, nil when validation is disabled.
+	ouCodes *organizationalUnitCodes
+
+	// tenant identifies the configured tenant in resource identities.
+	tenant *tenant
+
+	// allowedWebhookHosts restricts the hosts of webhook urls, any host is
+	// allowed when empty.
+	allowedWebhookHosts []string
+
+	// transport is the configured transport of the provider, with its proxy
+	// and CA certificates, for requests to other hosts than the Longship API.
+	transport http.RoundTripper
 }
 
 // Schema defines the provider-level schema for configuration data.
@@ -65,6 +135,107 @@ func (p *longshipProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the Longship config file to read the host, tenant key and application key from. May also be provided via LONGSHIP_PROFILE environment variable. Defaults to `default`.",
+				Optional:    true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path of the Longship config file holding named profiles. May also be provided via LONGSHIP_CONFIG_FILE environment variable. Defaults to `~/.longship/config`.",
+				Optional:    true,
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "Command run to obtain the tenant key and application key, e.g. from a secrets manager. It must print a JSON object with `tenant_key`, `application_key` and an optional RFC 3339 `expires_at` timestamp on stdout. The credentials are cached until they expire and refreshed when the API rejects them. May also be provided via LONGSHIP_CREDENTIAL_PROCESS environment variable or set in a profile of the Longship config file.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("tenant_key"),
+						path.MatchRoot("application_key"),
+					),
+				},
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Description: "Validate the host, tenant key and application key by listing the organizational units of the tenant while configuring the provider, so that wrong values are reported before any changes are made. Defaults to `true`. Disable for offline plans.",
+				Optional:    true,
+			},
+			"validate_ou_codes": schema.BoolAttribute{
+				Description: "Validate `ou_code` references against the organizational units of the tenant during plan. Defaults to `true`. Disable for offline plans.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average number of requests per second sent to the Longship API, shared by all resources and data sources. Unlimited by default.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests in flight to the Longship API at the same time, shared by all resources and data sources. Unlimited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cache_responses": schema.BoolAttribute{
+				Description: "Cache the responses of list endpoints, such as all webhooks or organizational units, for a short time during a single Terraform run. Writes invalidate the cache. Defaults to `true`.",
+				Optional:    true,
+			},
+			"http_log_max_body_bytes": schema.Int64Attribute{
+				Description: "Size in bytes at which request and response bodies are truncated in the HTTP wire logs, which are enabled with `TF_LOG_PROVIDER_LONGSHIP_HTTP=TRACE`. Defaults to `4096`, `0` disables truncation.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the HTTP proxy used to reach the Longship API. May also be provided via LONGSHIP_HTTP_PROXY environment variable. Defaults to the standard HTTPS_PROXY and NO_PROXY environment variables.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificate to trust in addition to the system roots, e.g. of a TLS-inspecting proxy. May also be provided via LONGSHIP_CA_CERT_PEM environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded CA certificate to trust in addition to the system roots. May also be provided via LONGSHIP_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate for mutual TLS. May also be provided via LONGSHIP_CLIENT_CERT environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate for mutual TLS. May also be provided via LONGSHIP_CLIENT_KEY environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the TLS certificate of the Longship API. Insecure, only use for debugging. May also be provided via LONGSHIP_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout of requests to the Longship API as a duration, e.g. `1m30s`. Defaults to `30s`. May also be provided via LONGSHIP_REQUEST_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description: "Text appended to the User-Agent header of requests to the Longship API, e.g. to identify the pipeline making them.",
+				Optional:    true,
+			},
+			"allowed_webhook_hosts": schema.SetAttribute{
+				Description: "Hosts which the `url` of webhooks may point to, checked during plan. An entry is either a host name such as `hooks.example.com`, or a wildcard such as `*.example.com` matching all of its subdomains. Any host is allowed by default.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 		},
 	}
 }
@@ -113,58 +284,86 @@ func (p *longshipProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	for name, value := range map[string]attr.Value{
+		"profile":              config.Profile,
+		"config_file":          config.ConfigFile,
+		"credential_process":   config.CredentialProcess,
+		"http_proxy":           config.HTTPProxy,
+		"ca_cert_pem":          config.CACertPEM,
+		"ca_cert_file":         config.CACertFile,
+		"client_cert":          config.ClientCert,
+		"client_key":           config.ClientKey,
+		"insecure_skip_verify": config.InsecureSkipVerify,
+		"request_timeout":      config.RequestTimeout,
+		"user_agent_suffix":    config.UserAgentSuffix,
+	} {
+		if value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown Longship Network Setting",
+				fmt.Sprintf("The provider cannot create the Longship API client as there is an unknown configuration value for %s. ", name)+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the corresponding `LONGSHIP_` environment variable.",
+			)
+		}
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
-
-	host := os.Getenv("LONGSHIP_HOST")
-	tenantKey := os.Getenv("LONGSHIP_TENANT_KEY")
-	applicationKey := os.Getenv("LONGSHIP_APPLICATION_KEY")
-
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+	if config.AllowedWebhookHosts.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allowed_webhook_hosts"),
+			"Unknown Allowed Webhook Hosts",
+			"The provider cannot check the url of webhooks as there is an unknown configuration value for allowed_webhook_hosts. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
 	}
 
-	if !config.TenantKey.IsNull() {
-		tenantKey = config.TenantKey.ValueString()
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !config.ApplicationKey.IsNull() {
-		applicationKey = config.ApplicationKey.ValueString()
+	creds, err := resolveCredentials(config)
+	if err != nil {
+		summary := "Unable to Read Longship Profile"
+		detail := "The provider cannot create the Longship API client as the profile could not be read from the Longship config file. " +
+			"Set the profile and config_file values in the configuration, or use the LONGSHIP_PROFILE and LONGSHIP_CONFIG_FILE environment variables.\n\n" +
+			"Error: " + err.Error()
+
+		if p, ok := credentialsErrorPath(config, err); ok {
+			resp.Diagnostics.AddAttributeError(p, summary, detail)
+		} else {
+			resp.Diagnostics.AddError(summary, detail)
+		}
+		return
 	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if host == "" {
+	if creds.Host == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing Longship API Host",
 			"The provider cannot create the Longship API client as there is a missing or empty value for the Longship API host. "+
-				"Set the host value in the configuration or use the LONGSHIP_HOST environment variable. "+
+				"Set the host value in the configuration, use the LONGSHIP_HOST environment variable, or set host in a profile of the Longship config file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if tenantKey == "" {
+	if creds.TenantKey == "" && creds.CredentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("tenantKey"),
 			"Missing Longship API Tenant Key",
 			"The provider cannot create the Longship API client as there is a missing or empty value for the Longship API Tenant Key. "+
-				"Set the tenant_key value in the configuration or use the LONGSHIP_TENANT_KEY environment variable. "+
+				"Set the tenant_key value in the configuration, use the LONGSHIP_TENANT_KEY environment variable, set tenant_key in a profile of the Longship config file, or configure a credential_process. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if applicationKey == "" {
+	if creds.ApplicationKey == "" && creds.CredentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("applicationKey"),
 			"Missing Longship API Application Key",
 			"The provider cannot create the Longship API client as there is a missing or empty value for the Longship API Application Key. "+
-				"Set the application_key value in the configuration or use the LONGSHIP_APPLICATION_KEY environment variable. "+
+				"Set the application_key value in the configuration, use the LONGSHIP_APPLICATION_KEY environment variable, set application_key in a profile of the Longship config file, or configure a credential_process. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -173,16 +372,61 @@ func (p *longshipProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "longship_host", host)
-	ctx = tflog.SetField(ctx, "longship_tenant_key", tenantKey)
-	ctx = tflog.SetField(ctx, "longship_application_key", applicationKey)
+	ctx = tflog.SetField(ctx, "longship_host", creds.Host)
+	ctx = tflog.SetField(ctx, "longship_tenant_key", creds.TenantKey)
+	ctx = tflog.SetField(ctx, "longship_application_key", creds.ApplicationKey)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "longship_tenant_key")
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "longship_application_key")
 
 	tflog.Debug(ctx, "Creating Longship client")
 
+	transport, timeout := p.configureTransport(config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxBodyBytes := defaultHTTPLogMaxBodyBytes
+	if !config.HTTPLogMaxBodyBytes.IsNull() {
+		maxBodyBytes = int(config.HTTPLogMaxBodyBytes.ValueInt64())
+	}
+
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: newLoggingTransport(transport, maxBodyBytes),
+	}
+
+	opts := []longship.Option{
+		longship.WithUserAgent(userAgent(p.version, req.TerraformVersion, config.UserAgentSuffix.ValueString())),
+		longship.WithHTTPClient(httpClient),
+		longship.WithLogger(tflogLogger{}),
+	}
+
+	var credentials longship.CredentialsProvider = longship.StaticCredentials{
+		TenantKey:      creds.TenantKey,
+		ApplicationKey: creds.ApplicationKey,
+	}
+
+	if creds.CredentialProcess != "" {
+		credentials = newCredentialProcess(creds.CredentialProcess)
+		opts = append(opts, longship.WithCredentialsProvider(credentials))
+	} else {
+		opts = append(opts, longship.WithCredentials(creds.TenantKey, creds.ApplicationKey))
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		opts = append(opts, longship.WithRateLimit(config.RequestsPerSecond.ValueFloat64()))
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		opts = append(opts, longship.WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())))
+	}
+
+	if config.CacheResponses.IsNull() || config.CacheResponses.ValueBool() {
+		opts = append(opts, longship.WithResponseCache(responseCacheTTL))
+	}
+
 	// Create a new Longship client using the configuration values
-	client, err := NewClient(&host, &tenantKey, &applicationKey)
+	client, err := longship.NewClient(creds.Host, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Longship API Client",
@@ -193,26 +437,286 @@ func (p *longshipProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if config.ValidateCredentials.IsNull() || config.ValidateCredentials.ValueBool() {
+		tflog.Debug(ctx, "Validating Longship API credentials")
+
+		resp.Diagnostics.Append(validateCredentials(ctx, client)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data := &providerData{
+		client:    client,
+		tenant:    &tenant{host: client.HostURL(), credentials: credentials},
+		transport: transport,
+	}
+
+	if config.ValidateOUCodes.IsNull() || config.ValidateOUCodes.ValueBool() {
+		data.ouCodes = &organizationalUnitCodes{client: client}
+	}
+
+	if !config.AllowedWebhookHosts.IsNull() {
+		resp.Diagnostics.Append(config.AllowedWebhookHosts.ElementsAs(ctx, &data.allowedWebhookHosts, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Make the Longship client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
+	resp.EphemeralResourceData = data
 
 	tflog.Info(ctx, "Configured Longship client", map[string]any{"success": true})
 }
 
+// NewClient returns a Longship API client for commands of the provider
+// binary, such as export. Like an empty provider configuration, it reads the
+// host, credentials and network settings such as the proxy, CA certificate,
+// client certificate and request timeout from environment variables and the
+// selected profile of the Longship config file.
+func NewClient(profile, configFile, version string) (*longship.Client, error) {
+
+	config := longshipProviderModel{
+		Profile:    types.StringNull(),
+		ConfigFile: types.StringNull(),
+	}
+
+	if profile != "" {
+		config.Profile = types.StringValue(profile)
+	}
+
+	if configFile != "" {
+		config.ConfigFile = types.StringValue(configFile)
+	}
+
+	creds, err := resolveCredentials(config)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &longshipProvider{version: version}
+
+	var resp provider.ConfigureResponse
+	transport, timeout := p.configureTransport(config, &resp)
+	for _, d := range resp.Diagnostics.Errors() {
+		return nil, fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	opts := []longship.Option{
+		longship.WithUserAgent(userAgent(version, "", "")),
+		longship.WithHTTPClient(&http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		}),
+	}
+
+	if creds.CredentialProcess != "" {
+		opts = append(opts, longship.WithCredentialsProvider(newCredentialProcess(creds.CredentialProcess)))
+	} else {
+		opts = append(opts, longship.WithCredentials(creds.TenantKey, creds.ApplicationKey))
+	}
+
+	return longship.NewClient(creds.Host, opts...)
+}
+
+// resolveCredentials returns the host and credentials of the provider.
+// Values set in the provider configuration take precedence over environment
+// variables, which take precedence over the selected profile of the Longship
+// config file.
+func resolveCredentials(config longshipProviderModel) (configProfile, error) {
+
+	profile, err := loadConfigProfile(
+		stringValueOrEnv(config.ConfigFile, "LONGSHIP_CONFIG_FILE"),
+		stringValueOrEnv(config.Profile, "LONGSHIP_PROFILE"),
+	)
+	if err != nil {
+		return configProfile{}, err
+	}
+
+	// Default values to environment variables, but override
+	// with Terraform configuration value if set.
+
+	if v := os.Getenv("LONGSHIP_HOST"); v != "" {
+		profile.Host = v
+	}
+
+	if v := os.Getenv("LONGSHIP_CREDENTIAL_PROCESS"); v != "" {
+		profile.useCredentialProcess(v)
+	}
+
+	if v := os.Getenv("LONGSHIP_TENANT_KEY"); v != "" {
+		profile.useTenantKey(v)
+	}
+
+	if v := os.Getenv("LONGSHIP_APPLICATION_KEY"); v != "" {
+		profile.useApplicationKey(v)
+	}
+
+	if !config.Host.IsNull() {
+		profile.Host = config.Host.ValueString()
+	}
+
+	if !config.CredentialProcess.IsNull() {
+		profile.useCredentialProcess(config.CredentialProcess.ValueString())
+	}
+
+	if !config.TenantKey.IsNull() {
+		profile.useTenantKey(config.TenantKey.ValueString())
+	}
+
+	if !config.ApplicationKey.IsNull() {
+		profile.useApplicationKey(config.ApplicationKey.ValueString())
+	}
+
+	return profile, nil
+}
+
+// credentialsErrorPath returns the attribute which supplied the value that
+// made resolving credentials fail with err: the profile when it does not
+// exist, and the config file otherwise. It returns false when the value was
+// supplied by an environment variable or a default instead.
+func credentialsErrorPath(config longshipProviderModel, err error) (path.Path, bool) {
+
+	var notFound *profileNotFoundError
+	if errors.As(err, &notFound) {
+		return path.Root("profile"), !config.Profile.IsNull()
+	}
+
+	return path.Root("config_file"), !config.ConfigFile.IsNull()
+}
+
+// userAgent returns the User-Agent header of requests to the Longship API.
+func userAgent(providerVersion, terraformVersion, suffix string) string {
+
+	ua := fmt.Sprintf("terraform-provider-longship/%s", providerVersion)
+
+	if terraformVersion != "" {
+		ua += fmt.Sprintf(" Terraform/%s", terraformVersion)
+	}
+
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+
+	return ua
+}
+
+// configureTransport builds the HTTP transport and request timeout from the
+// network settings of the provider, defaulting to environment variables.
+func (p *longshipProvider) configureTransport(config longshipProviderModel, resp *provider.ConfigureResponse) (*http.Transport, time.Duration) {
+
+	cfg := transportConfig{
+		HTTPProxy:  stringValueOrEnv(config.HTTPProxy, "LONGSHIP_HTTP_PROXY"),
+		CACertPEM:  stringValueOrEnv(config.CACertPEM, "LONGSHIP_CA_CERT_PEM"),
+		CACertFile: stringValueOrEnv(config.CACertFile, "LONGSHIP_CA_CERT_FILE"),
+		ClientCert: stringValueOrEnv(config.ClientCert, "LONGSHIP_CLIENT_CERT"),
+		ClientKey:  stringValueOrEnv(config.ClientKey, "LONGSHIP_CLIENT_KEY"),
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		cfg.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	} else if v := os.Getenv("LONGSHIP_INSECURE_SKIP_VERIFY"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid Longship Insecure Skip Verify Setting",
+				"The LONGSHIP_INSECURE_SKIP_VERIFY environment variable must be a boolean, got: "+v,
+			)
+			return nil, 0
+		}
+		cfg.InsecureSkipVerify = insecure
+	}
+
+	if cfg.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure Longship API Connection",
+			"TLS certificate verification of the Longship API is disabled. "+
+				"Credentials and data sent to the Longship API may be intercepted, only use this setting for debugging.",
+		)
+	}
+
+	timeout := defaultRequestTimeout
+	if v := stringValueOrEnv(config.RequestTimeout, "LONGSHIP_REQUEST_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Longship Request Timeout",
+				fmt.Sprintf("The request timeout must be a positive duration such as `30s` or `1m`, got: %q", v),
+			)
+			return nil, 0
+		}
+		timeout = d
+	}
+
+	transport, err := newTransport(cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Longship Network Settings",
+			"The provider cannot create the Longship API client due to invalid network settings: "+err.Error(),
+		)
+		return nil, 0
+	}
+
+	return transport, timeout
+}
+
+// stringValueOrEnv returns the configured value, falling back to the
+// environment variable env when the value is null.
+func stringValueOrEnv(value types.String, env string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *longshipProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewChargepointsDataSource,
 		NewWebhooksDataSource,
+		NewWebhookDeliveriesDataSource,
 		NewOrganizationalUnitsDataSource,
 	}
 }
 
+// Functions defines the functions implemented in the provider.
+func (p *longshipProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseEvseIDFunction,
+		NewFormatEvseIDFunction,
+		NewValidateEMAIDFunction,
+		NewNormalizeOUCodeFunction,
+		NewValidateIBANFunction,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the
+// provider, whose data is not persisted in plan or state.
+func (p *longshipProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewCredentialsEphemeralResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider,
+// which support `terraform query`.
+func (p *longshipProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewWebhookListResource,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *longshipProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewWebhookResource,
+		NewWebhookSetResource,
 	}
 }
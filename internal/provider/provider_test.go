@@ -1,6 +1,14 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -24,3 +32,144 @@ var (
 		"longship": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+func TestUserAgent(t *testing.T) {
+	testCases := map[string]struct {
+		terraformVersion string
+		suffix           string
+		expected         string
+	}{
+		"provider and terraform version": {
+			terraformVersion: "1.5.7",
+			expected:         "terraform-provider-longship/test Terraform/1.5.7",
+		},
+		"unknown terraform version": {
+			expected: "terraform-provider-longship/test",
+		},
+		"suffix": {
+			terraformVersion: "1.5.7",
+			suffix:           " ci/deploy ",
+			expected:         "terraform-provider-longship/test Terraform/1.5.7 ci/deploy",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := userAgent("test", tc.terraformVersion, tc.suffix); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := map[string]struct {
+		requestTimeout string
+		wantErr        bool
+	}{
+		"network settings": {},
+		"invalid request timeout": {
+			requestTimeout: "soon",
+			wantErr:        true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var proxied string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied = r.URL.String()
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer proxy.Close()
+
+			t.Setenv("LONGSHIP_HOST", "http://longship.invalid")
+			t.Setenv("LONGSHIP_TENANT_KEY", "tenant")
+			t.Setenv("LONGSHIP_APPLICATION_KEY", "application")
+			t.Setenv("LONGSHIP_HTTP_PROXY", proxy.URL)
+			t.Setenv("LONGSHIP_REQUEST_TIMEOUT", tc.requestTimeout)
+
+			client, err := NewClient("", filepath.Join(t.TempDir(), "config"), "test")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if _, err := client.Webhooks.List(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if proxied != "http://longship.invalid/v1/webhooks" {
+				t.Errorf("expected the request to be sent through the proxy, got %q", proxied)
+			}
+		})
+	}
+}
+
+func TestProviderSchemas(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range schemas.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	if _, ok := schemas.ListResourceSchemas["longship_webhook"]; !ok {
+		t.Error("expected list resource schema for longship_webhook")
+	}
+
+	if _, ok := schemas.EphemeralResourceSchemas["longship_credentials"]; !ok {
+		t.Error("expected ephemeral resource schema for longship_credentials")
+	}
+
+	for _, name := range []string{"parse_evse_id", "format_evse_id", "validate_emaid", "normalize_ou_code", "validate_iban"} {
+		if _, ok := schemas.Functions[name]; !ok {
+			t.Errorf("expected function %s", name)
+		}
+	}
+
+	identities, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range identities.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	if _, ok := identities.IdentitySchemas["longship_webhook"]; !ok {
+		t.Error("expected identity schema for longship_webhook")
+	}
+}
+
+// runFunction runs the provider function f with args and returns its result.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
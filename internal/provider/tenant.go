@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// tenantFingerprintLength is the number of hex characters of the tenant key
+// digest used as tenant fingerprint.
+const tenantFingerprintLength = 16
+
+// tenantFingerprintKey keys the digest of tenant keys. The key is public, so
+// it only rules out precomputed tables of plain SHA-256 digests.
+var tenantFingerprintKey = []byte("terraform-provider-longship/tenant-fingerprint/v1")
+
+// tenant identifies the Longship tenant the provider is configured for, so
+// that resource identities can record where a resource lives without
+// exposing the tenant key.
+type tenant struct {
+	host        string
+	credentials longship.CredentialsProvider
+}
+
+// fingerprint returns a stable identifier of the tenant, derived from an
+// HMAC-SHA256 digest of its tenant key.
+func (t *tenant) fingerprint(ctx context.Context) (string, error) {
+
+	creds, err := t.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return tenantFingerprint(creds.TenantKey), nil
+}
+
+// tenantFingerprint returns the fingerprint of a tenant key.
+func tenantFingerprint(tenantKey string) string {
+	mac := hmac.New(sha256.New, tenantFingerprintKey)
+	mac.Write([]byte(tenantKey))
+	return hex.EncodeToString(mac.Sum(nil))[:tenantFingerprintLength]
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestTenantFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	tenant := &tenant{
+		host:        "https://api.example.com",
+		credentials: longship.StaticCredentials{TenantKey: "tenant", ApplicationKey: "application"},
+	}
+
+	fingerprint, err := tenant.fingerprint(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// hmac-sha256(tenantFingerprintKey, "tenant")
+	if expected := "29720d5beca45c54"; fingerprint != expected {
+		t.Errorf("expected fingerprint %s, got %s", expected, fingerprint)
+	}
+
+	if other := tenantFingerprint("other"); other == fingerprint {
+		t.Errorf("expected different tenant keys to have different fingerprints, got %s", other)
+	}
+}
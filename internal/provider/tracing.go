@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the provider.
+const tracerName = "github.com/cbcoutinho/terraform-provider-longship"
+
+// SetupTracing installs a global OpenTelemetry tracer provider exporting
+// spans via OTLP over HTTP, when the OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variable is set. The
+// exporter is configured with the standard OTEL_EXPORTER_OTLP_ environment
+// variables. The returned function flushes and stops the exporter.
+func SetupTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("terraform-provider-longship"),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// startSpan starts a span for an operation, such as Create or Read, of a
+// resource or data source. End it with endSpan.
+func startSpan(ctx context.Context, typeName, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	attrs = append([]attribute.KeyValue{
+		attribute.String("longship.resource_type", typeName),
+		attribute.String("longship.operation", operation),
+	}, attrs...)
+
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+operation, trace.WithAttributes(attrs...))
+}
+
+// endSpan marks span as failed when diags holds errors, and ends it.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+
+	if diags.HasError() {
+		errs := diags.Errors()
+		span.SetStatus(codes.Error, errs[0].Summary())
+		for _, d := range errs {
+			span.AddEvent("error", trace.WithAttributes(
+				attribute.String("summary", d.Summary()),
+				attribute.String("detail", d.Detail()),
+			))
+		}
+	}
+
+	span.End()
+}
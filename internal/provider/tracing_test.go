@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	testCases := map[string]struct {
+		diags  diag.Diagnostics
+		status codes.Code
+	}{
+		"success": {
+			status: codes.Unset,
+		},
+		"error": {
+			diags:  diag.Diagnostics{diag.NewErrorDiagnostic("Error creating webhook", "unexpected error")},
+			status: codes.Error,
+		},
+		"warning": {
+			diags:  diag.Diagnostics{diag.NewWarningDiagnostic("Unable to Validate Organizational Unit", "timeout")},
+			status: codes.Unset,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			exporter.Reset()
+
+			_, span := startSpan(context.Background(), "longship_webhook", "Create",
+				attribute.String("longship.webhook_id", "00000000-0000-0000-0000-000000000000"),
+			)
+			endSpan(span, tc.diags)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("expected one span, got %d", len(spans))
+			}
+
+			if spans[0].Name != "longship_webhook.Create" {
+				t.Errorf("expected span name %q, got %q", "longship_webhook.Create", spans[0].Name)
+			}
+
+			if spans[0].Status.Code != tc.status {
+				t.Errorf("expected span status %s, got %s", tc.status, spans[0].Status.Code)
+			}
+
+			attributes := attribute.NewSet(spans[0].Attributes...)
+			for key, expected := range map[attribute.Key]string{
+				"longship.resource_type": "longship_webhook",
+				"longship.operation":     "Create",
+				"longship.webhook_id":    "00000000-0000-0000-0000-000000000000",
+			} {
+				if v, ok := attributes.Value(key); !ok || v.AsString() != expected {
+					t.Errorf("expected attribute %s to be %q, got %q", key, expected, v.AsString())
+				}
+			}
+		})
+	}
+}
+
+func TestSetupTracingDisabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := SetupTracing(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if otel.GetTracerProvider() != previous {
+		t.Error("expected the global tracer provider to be left alone without an OTLP endpoint")
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// transportConfig holds the network settings used to reach the Longship API.
+type transportConfig struct {
+	HTTPProxy          string
+	CACertPEM          string
+	CACertFile         string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+// newTransport returns an HTTP transport for cfg, based on the default
+// transport of the standard library.
+func newTransport(cfg transportConfig) (*http.Transport, error) {
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport type: %T", http.DefaultTransport)
+	}
+	transport = transport.Clone()
+
+	if cfg.HTTPProxy != "" {
+		proxyURL, err := url.Parse(cfg.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+
+		// #nosec G402 -- only when explicitly requested by the practitioner.
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertPEM != "" && cfg.CACertFile != "" {
+		return nil, fmt.Errorf("only one of CA certificate PEM and CA certificate file may be set")
+	}
+
+	caCert := []byte(cfg.CACertPEM)
+	if cfg.CACertFile != "" {
+		b, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate file: %w", err)
+		}
+		caCert = b
+	}
+
+	if len(caCert) > 0 {
+		// Trust the additional CA on top of the system roots, e.g. for
+		// TLS-inspecting proxies.
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.ClientCert == "") != (cfg.ClientKey == "") {
+		return nil, fmt.Errorf("client certificate and client key must be set together")
+	}
+
+	if cfg.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
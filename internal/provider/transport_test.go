@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTransportCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}))
+
+	testCases := map[string]struct {
+		cfg     transportConfig
+		wantErr bool
+	}{
+		"untrusted certificate": {
+			cfg:     transportConfig{},
+			wantErr: true,
+		},
+		"trusted CA certificate": {
+			cfg: transportConfig{CACertPEM: caCertPEM},
+		},
+		"insecure skip verify": {
+			cfg: transportConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			transport, err := newTransport(tc.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			res, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res.Body.Close()
+		})
+	}
+}
+
+func TestNewTransportInvalidConfig(t *testing.T) {
+	testCases := map[string]transportConfig{
+		"invalid CA certificate":         {CACertPEM: "not a certificate"},
+		"missing CA certificate file":    {CACertFile: "testdata/does-not-exist.pem"},
+		"conflicting CA certificates":    {CACertPEM: "a", CACertFile: "b"},
+		"client certificate without key": {ClientCert: "not a certificate"},
+		"invalid proxy URL":              {HTTPProxy: "://proxy"},
+	}
+
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newTransport(cfg); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// webhookHeaderNameRegexp matches a valid HTTP header field name, a token of
+// RFC 9110.
+var webhookHeaderNameRegexp = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// webhookReservedHeaders lists the headers which are set by Longship when
+// delivering events, and can therefore not be configured on a webhook.
+var webhookReservedHeaders = []string{
+	"Connection",
+	"Content-Length",
+	"Content-Type",
+	"Host",
+	"Keep-Alive",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// webhookURLValidator requires the url of a webhook to use https, unless
+// allow_insecure_url is set. Both attributes are read below parent, the root
+// of the configuration when empty.
+type webhookURLValidator struct {
+	parent path.Path
+}
+
+var _ resource.ConfigValidator = webhookURLValidator{}
+
+func (v webhookURLValidator) Description(_ context.Context) string {
+	return "url must be an absolute https URL unless allow_insecure_url is true"
+}
+
+func (v webhookURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v webhookURLValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var rawURL types.String
+	var allowInsecure types.Bool
+
+	urlPath := v.parent.AtName("url")
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, urlPath, &rawURL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.parent.AtName("allow_insecure_url"), &allowInsecure)...)
+	if resp.Diagnostics.HasError() || rawURL.IsNull() || rawURL.IsUnknown() || allowInsecure.IsUnknown() {
+		return
+	}
+
+	u, err := url.Parse(rawURL.ValueString())
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		resp.Diagnostics.AddAttributeError(
+			urlPath,
+			"Invalid Webhook URL",
+			fmt.Sprintf("The url must be an absolute URL such as `https://example.com/events`, got: %q", rawURL.ValueString()),
+		)
+		return
+	}
+
+	if u.Scheme == "http" && !allowInsecure.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			urlPath,
+			"Insecure Webhook URL",
+			fmt.Sprintf("The url %q uses http, which sends events and header values such as authorization tokens unencrypted. "+
+				"Use an https URL, or set allow_insecure_url to true, e.g. for an endpoint on a private network.", rawURL.ValueString()),
+		)
+	}
+}
+
+// webhookHeadersValidator requires the headers of a webhook to have valid,
+// unique and unreserved names, and values within the limits of the API. The
+// headers are read below parent, the root of the configuration when empty.
+type webhookHeadersValidator struct {
+	parent path.Path
+}
+
+var _ resource.ConfigValidator = webhookHeadersValidator{}
+
+func (v webhookHeadersValidator) Description(_ context.Context) string {
+	return "headers must have valid, unique and unreserved names"
+}
+
+func (v webhookHeadersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v webhookHeadersValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var headers types.Map
+
+	headersPath := v.parent.AtName("headers")
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, headersPath, &headers)...)
+	if resp.Diagnostics.HasError() || headers.IsNull() || headers.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateWebhookHeaders(headersPath, headers)...)
+}
+
+// validateWebhookHeaders returns an error for every header in headers, the
+// attribute at p, which the Longship API would reject or which would collide
+// with another header.
+func validateWebhookHeaders(p path.Path, headers types.Map) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	seen := map[string]string{}
+
+	for _, name := range slices.Sorted(maps.Keys(headers.Elements())) {
+		canonical := http.CanonicalHeaderKey(name)
+
+		switch {
+		case !webhookHeaderNameRegexp.MatchString(name):
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Invalid Webhook Header Name",
+				fmt.Sprintf("The header name %q must only contain letters, digits and the characters !#$%%&'*+-.^_`|~.", name),
+			)
+		case slices.Contains(webhookReservedHeaders, canonical):
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Reserved Webhook Header",
+				fmt.Sprintf("The header %q is set by Longship when delivering events and cannot be configured.", name),
+			)
+		case seen[canonical] != "":
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Duplicate Webhook Header",
+				fmt.Sprintf("The headers %q and %q only differ by case, while header names are case-insensitive. Remove one of them.", seen[canonical], name),
+			)
+		}
+
+		if seen[canonical] == "" {
+			seen[canonical] = name
+		}
+	}
+
+	return diags
+}
+
+// checkWebhookHost returns an error for the url at p when allowedHosts is not
+// empty and the host of the url matches none of its entries. An entry is
+// either a host name, or a wildcard such as `*.example.com` which matches all
+// of its subdomains.
+func checkWebhookHost(p path.Path, rawURL string, allowedHosts []string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if len(allowedHosts) == 0 {
+		return diags
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Invalid URLs are reported by webhookURLValidator.
+		return diags
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasPrefix(suffix, ".") {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return diags
+			}
+			continue
+		}
+
+		if host == allowed {
+			return diags
+		}
+	}
+
+	diags.AddAttributeError(
+		p,
+		"Webhook Host Not Allowed",
+		fmt.Sprintf("The host %q of the url is not in allowed_webhook_hosts of the provider configuration. Allowed hosts are: %s.",
+			host, strings.Join(allowedHosts, ", ")),
+	)
+
+	return diags
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// webhookConfig returns a configuration of the webhook resource with values,
+// leaving all other attributes null.
+func webhookConfig(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewWebhookResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if v, ok := values[name]; ok {
+			attributes[name] = v
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+}
+
+func TestWebhookURLValidator(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		url           tftypes.Value
+		allowInsecure tftypes.Value
+		expected      string
+	}{
+		"https": {
+			url: tftypes.NewValue(tftypes.String, "https://example.com/events"),
+		},
+		"http": {
+			url:      tftypes.NewValue(tftypes.String, "http://example.com/events"),
+			expected: "Insecure Webhook URL",
+		},
+		"http allowed": {
+			url:           tftypes.NewValue(tftypes.String, "http://10.0.0.1:8080/events"),
+			allowInsecure: tftypes.NewValue(tftypes.Bool, true),
+		},
+		"http explicitly disallowed": {
+			url:           tftypes.NewValue(tftypes.String, "http://example.com/events"),
+			allowInsecure: tftypes.NewValue(tftypes.Bool, false),
+			expected:      "Insecure Webhook URL",
+		},
+		"relative": {
+			url:      tftypes.NewValue(tftypes.String, "/events"),
+			expected: "Invalid Webhook URL",
+		},
+		"unsupported scheme": {
+			url:      tftypes.NewValue(tftypes.String, "ftp://example.com"),
+			expected: "Invalid Webhook URL",
+		},
+		"unknown": {
+			url: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{"url": tc.url}
+			if tc.allowInsecure.Type() != nil {
+				values["allow_insecure_url"] = tc.allowInsecure
+			}
+
+			req := fwresource.ValidateConfigRequest{Config: webhookConfig(t, values)}
+			var resp fwresource.ValidateConfigResponse
+
+			webhookURLValidator{}.ValidateResource(ctx, req, &resp)
+
+			assertDiagnosticSummary(t, resp.Diagnostics.Errors(), tc.expected)
+		})
+	}
+}
+
+func TestValidateWebhookHeaders(t *testing.T) {
+	testCases := map[string]struct {
+		headers  map[string]string
+		expected string
+	}{
+		"valid": {
+			headers: map[string]string{"Authorization": "Bearer token", "X-Api-Key": "key"},
+		},
+		"invalid name": {
+			headers:  map[string]string{"X Api Key": "key"},
+			expected: "Invalid Webhook Header Name",
+		},
+		"reserved": {
+			headers:  map[string]string{"content-type": "text/plain"},
+			expected: "Reserved Webhook Header",
+		},
+		"duplicate": {
+			headers:  map[string]string{"Authorization": "Bearer a", "authorization": "Bearer b"},
+			expected: "Duplicate Webhook Header",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			elements := map[string]attr.Value{}
+			for k, v := range tc.headers {
+				elements[k] = types.StringValue(v)
+			}
+
+			diags := validateWebhookHeaders(path.Root("headers"), types.MapValueMust(types.StringType, elements))
+
+			assertDiagnosticSummary(t, diags.Errors(), tc.expected)
+		})
+	}
+}
+
+func TestCheckWebhookHost(t *testing.T) {
+	testCases := map[string]struct {
+		url          string
+		allowedHosts []string
+		wantErr      bool
+	}{
+		"no allow-list": {
+			url: "https://anything.example.org",
+		},
+		"exact host": {
+			url:          "https://hooks.example.com/events",
+			allowedHosts: []string{"hooks.example.com"},
+		},
+		"exact host with port and different case": {
+			url:          "https://Hooks.Example.com:8443/events",
+			allowedHosts: []string{"hooks.example.com"},
+		},
+		"wildcard": {
+			url:          "https://a.b.example.com",
+			allowedHosts: []string{"other.org", "*.example.com"},
+		},
+		"wildcard does not match apex": {
+			url:          "https://example.com",
+			allowedHosts: []string{"*.example.com"},
+			wantErr:      true,
+		},
+		"suffix of another domain": {
+			url:          "https://evilexample.com",
+			allowedHosts: []string{"*.example.com", "example.com"},
+			wantErr:      true,
+		},
+		"not allowed": {
+			url:          "https://attacker.example.org",
+			allowedHosts: []string{"hooks.example.com"},
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkWebhookHost(path.Root("url"), tc.url, tc.allowedHosts)
+			if diags.HasError() != tc.wantErr {
+				t.Errorf("expected error %t, got diagnostics: %v", tc.wantErr, diags)
+			}
+		})
+	}
+}
+
+// assertDiagnosticSummary checks that errors is empty when expected is empty,
+// and otherwise consists of errors with the expected summary.
+func assertDiagnosticSummary(t *testing.T, errors diag.Diagnostics, expected string) {
+	t.Helper()
+
+	if expected == "" && len(errors) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", errors)
+	}
+
+	if expected != "" && len(errors) == 0 {
+		t.Fatalf("expected %q error, got none", expected)
+	}
+
+	for _, d := range errors {
+		if d.Summary() != expected {
+			t.Errorf("expected %q error, got %q: %s", expected, d.Summary(), d.Detail())
+		}
+	}
+}
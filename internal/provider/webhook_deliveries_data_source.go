@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &WebhookDeliveriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &WebhookDeliveriesDataSource{}
+)
+
+const (
+	webhookDeliveryOutcomeSuccess = "success"
+	webhookDeliveryOutcomeFailure = "failure"
+)
+
+// WebhookDeliveriesDataSource is the data source implementation.
+type WebhookDeliveriesDataSource struct {
+	client *longship.Client
+}
+
+type webhookDeliveriesDataSourceModel struct {
+	WebhookID types.String `tfsdk:"webhook_id"`
+	From      types.String `tfsdk:"from"`
+	To        types.String `tfsdk:"to"`
+	EventType types.String `tfsdk:"event_type"`
+	Outcome   types.String `tfsdk:"outcome"`
+
+	Deliveries   []WebhookDeliveryDataSourceModel `tfsdk:"deliveries"`
+	Total        types.Int64                      `tfsdk:"total"`
+	Successful   types.Int64                      `tfsdk:"successful"`
+	Failed       types.Int64                      `tfsdk:"failed"`
+	SuccessRate  types.Float64                    `tfsdk:"success_rate"`
+	SuccessRates types.Map                        `tfsdk:"success_rates"`
+}
+
+type WebhookDeliveryDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	EventType  types.String `tfsdk:"event_type"`
+	Timestamp  types.String `tfsdk:"timestamp"`
+	Success    types.Bool   `tfsdk:"success"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	LatencyMs  types.Int64  `tfsdk:"latency_ms"`
+	Error      types.String `tfsdk:"error"`
+}
+
+func NewWebhookDeliveriesDataSource() datasource.DataSource {
+	return &WebhookDeliveriesDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *WebhookDeliveriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// Metadata returns the data source type name.
+func (d *WebhookDeliveriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_deliveries"
+}
+
+// Schema defines the schema for the data source.
+func (d *WebhookDeliveriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the delivery attempts of a webhook, e.g. to check in a `check` block that Longship delivers events to its endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"webhook_id": schema.StringAttribute{
+				Description: "Unique identifier of the webhook.",
+				Required:    true,
+			},
+			"from": schema.StringAttribute{
+				Description: "Only return deliveries attempted at or after this RFC 3339 timestamp, e.g. `timeadd(plantimestamp(), \"-24h\")`.",
+				Optional:    true,
+			},
+			"to": schema.StringAttribute{
+				Description: "Only return deliveries attempted before this RFC 3339 timestamp.",
+				Optional:    true,
+			},
+			"event_type": schema.StringAttribute{
+				Description: "Only return deliveries of this event type.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(webhookEventTypes...),
+				},
+			},
+			"outcome": schema.StringAttribute{
+				Description: "Only return successful or failed deliveries. Possible values are `success` and `failure`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(webhookDeliveryOutcomeSuccess, webhookDeliveryOutcomeFailure),
+				},
+			},
+			"deliveries": schema.ListNestedAttribute{
+				Description: "Delivery attempts of the webhook.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the delivery attempt.",
+							Computed:    true,
+						},
+						"event_type": schema.StringAttribute{
+							Description: "Event type of the delivered event.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "Timestamp of the delivery attempt.",
+							Computed:    true,
+						},
+						"success": schema.BoolAttribute{
+							Description: "Whether the endpoint accepted the event.",
+							Computed:    true,
+						},
+						"status_code": schema.Int64Attribute{
+							Description: "HTTP status code the endpoint responded with, null when it did not respond.",
+							Computed:    true,
+						},
+						"latency_ms": schema.Int64Attribute{
+							Description: "Time in milliseconds until the endpoint responded or the attempt failed.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Error of a failed delivery attempt.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"total": schema.Int64Attribute{
+				Description: "Number of delivery attempts.",
+				Computed:    true,
+			},
+			"successful": schema.Int64Attribute{
+				Description: "Number of successful delivery attempts.",
+				Computed:    true,
+			},
+			"failed": schema.Int64Attribute{
+				Description: "Number of failed delivery attempts.",
+				Computed:    true,
+			},
+			"success_rate": schema.Float64Attribute{
+				Description: "Fraction of successful delivery attempts between 0 and 1, null when there are none.",
+				Computed:    true,
+			},
+			"success_rates": schema.MapAttribute{
+				Description: "Fraction of successful delivery attempts between 0 and 1 by event type.",
+				ElementType: types.Float64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *WebhookDeliveriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	ctx, span := startSpan(ctx, "longship_webhook_deliveries", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state webhookDeliveriesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	span.SetAttributes(attribute.String("longship.webhook_id", state.WebhookID.ValueString()))
+
+	opts := longship.WebhookDeliveriesOptions{
+		EventType: state.EventType.ValueString(),
+	}
+
+	opts.From = parseTimestampAttribute(state.From, path.Root("from"), &resp.Diagnostics)
+	opts.To = parseTimestampAttribute(state.To, path.Root("to"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("to"),
+			"Invalid Time Window",
+			fmt.Sprintf("The end of the time window %s must be after its start %s.", state.To.ValueString(), state.From.ValueString()),
+		)
+		return
+	}
+
+	if !state.Outcome.IsNull() {
+		success := state.Outcome.ValueString() == webhookDeliveryOutcomeSuccess
+		opts.Success = &success
+	}
+
+	deliveries, err := d.client.Webhooks.ListDeliveries(ctx, state.WebhookID.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Longship Webhook Deliveries",
+			"Could not read deliveries of Longship webhook ID "+state.WebhookID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Read %d deliveries of webhook id: %s", len(deliveries), state.WebhookID.ValueString()))
+
+	// Map response body to model
+	state.Deliveries = []WebhookDeliveryDataSourceModel{}
+	for _, delivery := range deliveries {
+		deliveryState := WebhookDeliveryDataSourceModel{
+			ID:         types.StringValue(delivery.ID),
+			EventType:  types.StringValue(delivery.EventType),
+			Timestamp:  types.StringValue(delivery.Timestamp),
+			Success:    types.BoolValue(delivery.Success),
+			StatusCode: types.Int64Null(),
+			LatencyMs:  types.Int64Value(delivery.LatencyMs),
+			Error:      types.StringNull(),
+		}
+
+		if delivery.StatusCode != 0 {
+			deliveryState.StatusCode = types.Int64Value(int64(delivery.StatusCode))
+		}
+
+		if delivery.Error != "" {
+			deliveryState.Error = types.StringValue(delivery.Error)
+		}
+
+		state.Deliveries = append(state.Deliveries, deliveryState)
+	}
+
+	resp.Diagnostics.Append(setWebhookDeliveryStats(&state, deliveries)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// setWebhookDeliveryStats sets the aggregate attributes of state from
+// deliveries.
+func setWebhookDeliveryStats(state *webhookDeliveriesDataSourceModel, deliveries []longship.WebhookDelivery) diag.Diagnostics {
+
+	var successful int64
+	attempts := map[string]int64{}
+	successes := map[string]int64{}
+
+	for _, delivery := range deliveries {
+		attempts[delivery.EventType]++
+		if delivery.Success {
+			successful++
+			successes[delivery.EventType]++
+		}
+	}
+
+	total := int64(len(deliveries))
+
+	state.Total = types.Int64Value(total)
+	state.Successful = types.Int64Value(successful)
+	state.Failed = types.Int64Value(total - successful)
+
+	state.SuccessRate = types.Float64Null()
+	if total > 0 {
+		state.SuccessRate = types.Float64Value(float64(successful) / float64(total))
+	}
+
+	rates := map[string]attr.Value{}
+	for eventType, n := range attempts {
+		rates[eventType] = types.Float64Value(float64(successes[eventType]) / float64(n))
+	}
+
+	var diags diag.Diagnostics
+	state.SuccessRates, diags = types.MapValue(types.Float64Type, rates)
+	return diags
+}
+
+// parseTimestampAttribute parses the RFC 3339 timestamp in v, adding an error
+// for the attribute at p to diags when it is invalid. It returns the zero time
+// for a null value.
+func parseTimestampAttribute(v types.String, p path.Path, diags *diag.Diagnostics) time.Time {
+
+	if v.IsNull() || v.IsUnknown() {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, v.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Timestamp",
+			fmt.Sprintf("Expected an RFC 3339 timestamp such as `2024-01-01T00:00:00Z`, got: %q", v.ValueString()),
+		)
+	}
+
+	return t
+}
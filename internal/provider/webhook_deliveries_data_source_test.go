@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestSetWebhookDeliveryStats(t *testing.T) {
+	testCases := map[string]struct {
+		deliveries  []longship.WebhookDelivery
+		total       int64
+		successful  int64
+		successRate types.Float64
+		rates       map[string]attr.Value
+	}{
+		"no deliveries": {
+			deliveries:  []longship.WebhookDelivery{},
+			successRate: types.Float64Null(),
+			rates:       map[string]attr.Value{},
+		},
+		"all successful": {
+			deliveries: []longship.WebhookDelivery{
+				{EventType: "SESSION_START", Success: true},
+				{EventType: "SESSION_STOP", Success: true},
+			},
+			total:       2,
+			successful:  2,
+			successRate: types.Float64Value(1),
+			rates: map[string]attr.Value{
+				"SESSION_START": types.Float64Value(1),
+				"SESSION_STOP":  types.Float64Value(1),
+			},
+		},
+		"partially failed": {
+			deliveries: []longship.WebhookDelivery{
+				{EventType: "SESSION_START", Success: true},
+				{EventType: "SESSION_START", Success: false, StatusCode: 500},
+				{EventType: "SESSION_STOP", Success: true},
+				{EventType: "CDR_CREATED", Success: false, Error: "connection refused"},
+			},
+			total:       4,
+			successful:  2,
+			successRate: types.Float64Value(0.5),
+			rates: map[string]attr.Value{
+				"SESSION_START": types.Float64Value(0.5),
+				"SESSION_STOP":  types.Float64Value(1),
+				"CDR_CREATED":   types.Float64Value(0),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var state webhookDeliveriesDataSourceModel
+
+			if diags := setWebhookDeliveryStats(&state, tc.deliveries); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if state.Total.ValueInt64() != tc.total {
+				t.Errorf("expected total %d, got %s", tc.total, state.Total)
+			}
+
+			if state.Successful.ValueInt64() != tc.successful {
+				t.Errorf("expected successful %d, got %s", tc.successful, state.Successful)
+			}
+
+			if state.Failed.ValueInt64() != tc.total-tc.successful {
+				t.Errorf("expected failed %d, got %s", tc.total-tc.successful, state.Failed)
+			}
+
+			if !state.SuccessRate.Equal(tc.successRate) {
+				t.Errorf("expected success_rate %s, got %s", tc.successRate, state.SuccessRate)
+			}
+
+			expected := types.MapValueMust(types.Float64Type, tc.rates)
+			if !state.SuccessRates.Equal(expected) {
+				t.Errorf("expected success_rates %s, got %s", expected, state.SuccessRates)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var (
+	_ list.ListResource              = &webhookListResource{}
+	_ list.ListResourceWithConfigure = &webhookListResource{}
+)
+
+// webhookListModel holds the filters of a longship_webhook list block.
+type webhookListModel struct {
+	OUCode    types.String `tfsdk:"ou_code"`
+	EventType types.String `tfsdk:"event_type"`
+}
+
+func NewWebhookListResource() list.ListResource {
+	return &webhookListResource{}
+}
+
+// webhookListResource lists the webhooks of the tenant for `terraform query`.
+type webhookListResource struct {
+	client *longship.Client
+	tenant *tenant
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *webhookListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieving Longship API client")
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.tenant = data.tenant
+}
+
+// Metadata returns the name of the listed resource type.
+func (r *webhookListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+// ListResourceConfigSchema defines the filters of the list block.
+func (r *webhookListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Attributes: map[string]listschema.Attribute{
+			"ou_code": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list webhooks of the Organizational Unit (OU) with this code.",
+			},
+			"event_type": listschema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(webhookEventTypes...),
+				},
+				Description: "Only list webhooks configured for this event type.",
+			},
+		},
+	}
+}
+
+// List streams the webhooks of the tenant matching the filters.
+func (r *webhookListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+
+	var config webhookListModel
+	var diags diag.Diagnostics
+
+	// The span covers listing the webhooks. Results are streamed after List
+	// returns, so fetching their full state is traced by setResource.
+	ctx, span := startSpan(ctx, "longship_webhook", "List")
+	defer func() { endSpan(span, diags) }()
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	webhooks, err := r.client.Webhooks.List(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to List Longship Webhooks",
+			err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	webhooks = filterWebhooks(webhooks, config.OUCode.ValueString(), config.EventType.ValueString())
+
+	tflog.Info(ctx, fmt.Sprintf("Listing %d webhooks", len(webhooks)))
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, w := range webhooks {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%s/%s", w.OUCode, w.Name)
+
+			result.Diagnostics.Append(setWebhookIdentity(ctx, result.Identity, r.tenant, w.ID)...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				result.Diagnostics.Append(r.setResource(ctx, result, w.ID)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setResource sets the full state of the webhook with id on result. Only
+// single webhooks are returned with their headers.
+func (r *webhookListResource) setResource(ctx context.Context, result list.ListResult, id string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	ctx, span := startSpan(ctx, "longship_webhook", "IncludeResource", attribute.String("longship.webhook_id", id))
+	defer func() { endSpan(span, diags) }()
+
+	webhook, err := r.client.Webhooks.Get(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Longship Webhook",
+			"Could not read Longship webhook ID "+id+": "+err.Error(),
+		)
+		return diags
+	}
+
+	state := WebhookResourceModel{
+		ID:               types.StringValue(webhook.ID),
+		Name:             types.StringValue(webhook.Name),
+		OUCode:           types.StringValue(webhook.OUCode),
+		Enabled:          types.BoolValue(webhook.Enabled),
+		URL:              types.StringValue(webhook.URL),
+		Updated:          types.StringPointerValue(webhook.Updated),
+		Created:          types.StringPointerValue(webhook.Created),
+		WriteOnlyHeaders: types.SetNull(types.StringType),
+	}
+
+	var d diag.Diagnostics
+
+	state.EventTypes, d = types.SetValueFrom(ctx, types.StringType, webhook.EventTypes)
+	diags.Append(d...)
+
+	state.Headers, d = flattenWebhookHeaders(ctx, webhook.Headers, types.MapNull(types.StringType), types.SetNull(types.StringType))
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(result.Resource.Set(ctx, state)...)
+
+	return diags
+}
+
+// filterWebhooks returns the webhooks in the OU with ouCode and configured
+// for eventType. Empty filters match all webhooks.
+func filterWebhooks(webhooks []longship.Webhook, ouCode, eventType string) []longship.Webhook {
+
+	filtered := []longship.Webhook{}
+	for _, w := range webhooks {
+		if ouCode != "" && w.OUCode != ouCode {
+			continue
+		}
+
+		if eventType != "" && !slices.Contains(w.EventTypes, eventType) {
+			continue
+		}
+
+		filtered = append(filtered, w)
+	}
+
+	return filtered
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestFilterWebhooks(t *testing.T) {
+	webhooks := []longship.Webhook{
+		{ID: "1", OUCode: "0000", EventTypes: []string{"SESSION_START", "SESSION_STOP"}},
+		{ID: "2", OUCode: "0000", EventTypes: []string{"CDR_CREATED"}},
+		{ID: "3", OUCode: "0001", EventTypes: []string{"SESSION_START"}},
+	}
+
+	testCases := map[string]struct {
+		ouCode    string
+		eventType string
+		expected  []string
+	}{
+		"no filters": {
+			expected: []string{"1", "2", "3"},
+		},
+		"ou code": {
+			ouCode:   "0000",
+			expected: []string{"1", "2"},
+		},
+		"event type": {
+			eventType: "SESSION_START",
+			expected:  []string{"1", "3"},
+		},
+		"ou code and event type": {
+			ouCode:    "0001",
+			eventType: "SESSION_START",
+			expected:  []string{"3"},
+		},
+		"no match": {
+			ouCode:    "0001",
+			eventType: "CDR_CREATED",
+			expected:  []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ids := []string{}
+			for _, w := range filterWebhooks(webhooks, tc.ouCode, tc.eventType) {
+				ids = append(ids, w.ID)
+			}
+
+			if len(ids) != len(tc.expected) {
+				t.Fatalf("expected webhooks %v, got %v", tc.expected, ids)
+			}
+
+			for i := range ids {
+				if ids[i] != tc.expected[i] {
+					t.Fatalf("expected webhooks %v, got %v", tc.expected, ids)
+				}
+			}
+		})
+	}
+}
@@ -3,38 +3,64 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 var (
-	_ resource.Resource                = &webhookResource{}
-	_ resource.ResourceWithConfigure   = &webhookResource{}
-	_ resource.ResourceWithImportState = &webhookResource{}
+	_ resource.Resource                     = &webhookResource{}
+	_ resource.ResourceWithConfigure        = &webhookResource{}
+	_ resource.ResourceWithConfigValidators = &webhookResource{}
+	_ resource.ResourceWithIdentity         = &webhookResource{}
+	_ resource.ResourceWithImportState      = &webhookResource{}
+	_ resource.ResourceWithModifyPlan       = &webhookResource{}
+	_ resource.ResourceWithUpgradeState     = &webhookResource{}
+	_ resource.ResourceWithValidateConfig   = &webhookResource{}
 )
 
 type WebhookResourceModel struct {
-	ID         types.String   `tfsdk:"id"`
-	Name       types.String   `tfsdk:"name"`
-	OUCode     types.String   `tfsdk:"ou_code"`
-	Enabled    types.Bool     `tfsdk:"enabled"`
-	EventTypes []types.String `tfsdk:"event_types"`
-	URL        types.String   `tfsdk:"url"`
-	Headers    types.Map      `tfsdk:"headers"`
-	Created    types.String   `tfsdk:"created"`
-	Updated    types.String   `tfsdk:"updated"`
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	OUCode     types.String `tfsdk:"ou_code"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	EventTypes types.Set    `tfsdk:"event_types"`
+	URL        types.String `tfsdk:"url"`
+	Headers    types.Map    `tfsdk:"headers"`
+	Created    types.String `tfsdk:"created"`
+	Updated    types.String `tfsdk:"updated"`
+
+	WriteOnlyHeaders types.Set  `tfsdk:"write_only_headers"`
+	AllowInsecureURL types.Bool `tfsdk:"allow_insecure_url"`
+
+	Verify *webhookVerifyModel `tfsdk:"verify"`
+}
+
+// webhookIdentityModel is the resource identity of a webhook.
+type webhookIdentityModel struct {
+	Host   types.String `tfsdk:"host"`
+	Tenant types.String `tfsdk:"tenant"`
+	ID     types.String `tfsdk:"id"`
 }
 
 type HeaderModel struct {
@@ -51,17 +77,21 @@ func (d *webhookResource) Configure(ctx context.Context, req resource.ConfigureR
 
 	tflog.Debug(ctx, "Retrieving Longship API client")
 
-	client, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+	d.ouCodes = data.ouCodes
+	d.tenant = data.tenant
+	d.allowedHosts = data.allowedWebhookHosts
+	d.transport = data.transport
 }
 
 func NewWebhookResource() resource.Resource {
@@ -69,7 +99,16 @@ func NewWebhookResource() resource.Resource {
 }
 
 type webhookResource struct {
-	client *Client
+	client  *longship.Client
+	ouCodes *organizationalUnitCodes
+	tenant  *tenant
+
+	// allowedHosts restricts the hosts of webhook urls, any host is allowed
+	// when empty.
+	allowedHosts []string
+
+	// transport sends the test events of webhook verification.
+	transport http.RoundTripper
 }
 
 // Metadata returns the resource type name.
@@ -80,6 +119,7 @@ func (r *webhookResource) Metadata(_ context.Context, req resource.MetadataReque
 // Schema defines the schema for the resource.
 func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -88,7 +128,10 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"name": schema.StringAttribute{
-				Required:    true,
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 				Description: "The name which should be used for this webhook.",
 			},
 			"ou_code": schema.StringAttribute{
@@ -101,41 +144,47 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Should the webhook be enabled? Defaults to `true`.",
 				Default:     booldefault.StaticBool(true),
 			},
-			"event_types": schema.ListAttribute{
+			"event_types": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
-				Validators: []validator.List{
-
-					// Validate this list must not be empty.
-					listvalidator.SizeAtLeast(1),
+				Validators: []validator.Set{
 
-					// Validate this list must contain only unique values.
-					listvalidator.UniqueValues(),
+					// Validate this set must not be empty.
+					setvalidator.SizeAtLeast(1),
 
-					// Validate this list must contain only supported webhook
+					// Validate this set must contain only supported webhook
 					// event types
-					listvalidator.ValueStringsAre(stringvalidator.OneOf([]string{
-						"SESSION_START",
-						"SESSION_UPDATE",
-						"SESSION_STOP",
-						"OPERATIONAL_STATUS",
-						"CONNECTIVITY_STATUS",
-						"CHARGEPOINT_BOOTED",
-						"CDR_CREATED",
-					}...)),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(webhookEventTypes...)),
 				},
 				Description: "The event types for which to configure this webhook. Possible values are `SESSION_START`, `SESSION_UPDATE`, `SESSION_STOP`, `OPERATIONAL_STATUS`, `CONNECTIVITY_STATUS`, `CHARGEPOINT_BOOTED`, and `CDR_CREATED`",
 			},
 			"url": schema.StringAttribute{
-				Required:    true,
-				Description: "The URL associated with the webhook.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "The URL associated with the webhook. Must use https unless `allow_insecure_url` is set, and match `allowed_webhook_hosts` of the provider when configured.",
+			},
+			"allow_insecure_url": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow an http `url`, e.g. for an endpoint on a private network. Events and header values are then sent unencrypted.",
 			},
 			"headers": schema.MapAttribute{
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
+				Sensitive:   true,
 				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
-				Description: "The HTTP headers to be used by the webhook.",
+				Description: "The HTTP headers to be used by the webhook. Header values are sensitive and are masked in plan output and logs. " +
+					"Header names are case-insensitive and must be unique, and headers set by Longship such as `Content-Type` cannot be configured.",
+			},
+			"write_only_headers": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "Names of `headers` which are write-only. Their values are sent when the webhook is created or updated, but ignored when the webhook is read back, e.g. because the API masks secrets.",
 			},
 			"created": schema.StringAttribute{
 				Computed: true,
@@ -149,12 +198,78 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "The timestamp associated with when the webhook was last updated.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"verify": schema.SingleNestedBlock{
+				Description: "Verify the endpoint of the webhook after it is created or updated, by sending a test event to `url` with the configured `headers`. " +
+					"The test event is a JSON object with `event_type` `WEBHOOK_TEST`, which the endpoint should acknowledge without processing. " +
+					"Apply reports a warning when the endpoint does not respond with a 2xx status within the timeout.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						Optional:    true,
+						Description: "How long to wait for the endpoint to respond, as a duration, e.g. `30s`. Defaults to `10s`.",
+					},
+					"secret": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+						Description: "Secret to sign the test event with. The HMAC-SHA256 digest of the request body is sent as `sha256=<hex digest>` in `signature_header`.",
+					},
+					"signature_header": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret")),
+						},
+						Description: "Header to send the signature of the test event in. Defaults to `X-Longship-Signature`.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of the resource, which allows import
+// blocks to address a webhook with `identity = { id = ... }`. The host and
+// tenant record where the webhook lives, so that pointing the provider at a
+// different tenant is detected instead of treating the webhook as deleted.
+func (r *webhookResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"host": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "URI of the Longship API the webhook was created with.",
+			},
+			"tenant": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Fingerprint of the tenant key the webhook was created with, the first 16 hex characters of its HMAC-SHA256 digest.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier of the webhook.",
+			},
+		},
 	}
 }
 
+// webhookEventTypes lists the event types supported by the Longship webhooks API.
+var webhookEventTypes = []string{
+	"SESSION_START",
+	"SESSION_UPDATE",
+	"SESSION_STOP",
+	"OPERATIONAL_STATUS",
+	"CONNECTIVITY_STATUS",
+	"CHARGEPOINT_BOOTED",
+	"CDR_CREATED",
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 
+	ctx, span := startSpan(ctx, "longship_webhook", "Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var plan WebhookResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -163,29 +278,22 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Planning webhookResource: %s", plan))
-
 	var eventTypes []string
-	for _, eventType := range plan.EventTypes {
-		eventTypes = append(eventTypes, eventType.ValueString())
+	diags = plan.EventTypes.ElementsAs(ctx, &eventTypes, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	h := map[string]string{}
-	diags = plan.Headers.ElementsAs(ctx, &h, false)
+	headers, diags := expandWebhookHeaders(ctx, plan.Headers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	headers := []Header{}
-	for name, value := range h {
-		headers = append(headers, Header{
-			Name:  name,
-			Value: value,
-		})
-	}
+	ctx = maskWebhookHeaders(ctx, headers)
 
-	config := WebhookConfig{
+	config := longship.WebhookConfig{
 		Name:       plan.Name.ValueString(),
 		OUCode:     plan.OUCode.ValueString(),
 		Enabled:    plan.Enabled.ValueBool(),
@@ -194,9 +302,9 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 		URL:        plan.URL.ValueString(),
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Creating webhook: %+v", config))
+	tflog.Info(ctx, fmt.Sprintf("Creating webhook: %s", config.Name))
 
-	webhook, err := r.client.CreateWebhook(config)
+	webhook, err := r.client.Webhooks.Create(ctx, config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating webhook",
@@ -205,26 +313,21 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Create webhook response: %+v", webhook))
+	tflog.Info(ctx, fmt.Sprintf("Created webhook id: %s", webhook.ID))
+	span.SetAttributes(attribute.String("longship.webhook_id", webhook.ID))
 
 	plan.ID = types.StringValue(webhook.ID)
 	plan.Name = types.StringValue(webhook.Name)
 	plan.OUCode = types.StringValue(webhook.OUCode)
 	plan.Enabled = types.BoolValue(webhook.Enabled)
 	plan.URL = types.StringValue(webhook.URL)
-	plan.Updated = types.StringValue(webhook.Updated)
-	plan.Created = types.StringValue(webhook.Created)
-
-	for idx, eventType := range webhook.EventTypes {
-		plan.EventTypes[idx] = types.StringValue(eventType)
-	}
+	plan.Updated = types.StringPointerValue(webhook.Updated)
+	plan.Created = types.StringPointerValue(webhook.Created)
 
-	m := map[string]attr.Value{}
-	for _, header := range webhook.Headers {
-		m[header.Name] = types.StringValue(header.Value)
-	}
+	plan.EventTypes, diags = types.SetValueFrom(ctx, types.StringType, webhook.EventTypes)
+	resp.Diagnostics.Append(diags...)
 
-	plan.Headers, diags = types.MapValue(types.StringType, m)
+	plan.Headers, diags = flattenWebhookHeaders(ctx, webhook.Headers, plan.Headers, plan.WriteOnlyHeaders)
 	resp.Diagnostics.Append(diags...)
 
 	// Set state to fully populated data
@@ -233,11 +336,21 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setWebhookIdentity(ctx, resp.Identity, r.tenant, webhook.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(verifyWebhook(ctx, r.transport, plan, headers)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 
+	ctx, span := startSpan(ctx, "longship_webhook", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state WebhookResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -246,36 +359,26 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	tflog.Info(ctx, "Reading all webhooks")
+	tflog.Info(ctx, fmt.Sprintf("Reading webhook id: %s", state.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_id", state.ID.ValueString()))
 
-	exists := false
-	webhooks, err := r.client.GetWebhooks()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading webhooks",
-			"Could not read webhook, unexpected error: "+err.Error(),
-		)
+	// Refuse to refresh a webhook of another tenant, which would otherwise
+	// not be found and be planned for recreation in the configured tenant.
+	resp.Diagnostics.Append(checkWebhookIdentity(ctx, req.Identity, r.tenant)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	for _, w := range webhooks {
-		tflog.Info(ctx, fmt.Sprintf("Found webhook[%s]: %+v", w.ID, w))
-		if state.ID.ValueString() == w.ID {
-			exists = true
-		}
-	}
+	// Get refreshed webhook value from Longship
+	webhook, err := r.client.Webhooks.Get(ctx, state.ID.ValueString())
 
 	// https://discuss.hashicorp.com/t/how-should-read-signal-that-a-resource-has-vanished-from-the-api-server/40833
-	if !exists {
+	if longship.IsNotFound(err) {
 		tflog.Info(ctx, "Webhook does not exist!")
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Reading webhook id: %s", state.ID.ValueString()))
-
-	// Get refreshed webhook value from Longship
-	webhook, err := r.client.GetWebhook(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Longship Webhook",
@@ -284,27 +387,22 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("GET /v1/webhooks/%s response: %+v", state.ID.ValueString(), webhook))
+	ctx = maskWebhookHeaders(ctx, webhook.Headers)
+
+	tflog.Info(ctx, fmt.Sprintf("Read webhook id: %s, name: %s", webhook.ID, webhook.Name))
 
 	// Overwrite attributes with refreshed state
 	state.Name = types.StringValue(webhook.Name)
 	state.OUCode = types.StringValue(webhook.OUCode)
 	state.Enabled = types.BoolValue(webhook.Enabled)
 	state.URL = types.StringValue(webhook.URL)
-	state.Updated = types.StringValue(webhook.Updated)
-	state.Created = types.StringValue(webhook.Created)
-
-	state.EventTypes = []types.String{}
-	for _, eventType := range webhook.EventTypes {
-		state.EventTypes = append(state.EventTypes, types.StringValue(eventType))
-	}
+	state.Updated = types.StringPointerValue(webhook.Updated)
+	state.Created = types.StringPointerValue(webhook.Created)
 
-	m := map[string]attr.Value{}
-	for _, header := range webhook.Headers {
-		m[header.Name] = types.StringValue(header.Value)
-	}
+	state.EventTypes, diags = types.SetValueFrom(ctx, types.StringType, webhook.EventTypes)
+	resp.Diagnostics.Append(diags...)
 
-	state.Headers, diags = types.MapValue(types.StringType, m)
+	state.Headers, diags = flattenWebhookHeaders(ctx, webhook.Headers, state.Headers, state.WriteOnlyHeaders)
 	resp.Diagnostics.Append(diags...)
 
 	// Set refreshed state
@@ -313,11 +411,16 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(keepWebhookIdentity(ctx, req.Identity, resp.Identity, r.tenant, webhook.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 
+	ctx, span := startSpan(ctx, "longship_webhook", "Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var plan WebhookResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -327,28 +430,24 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Updating webhook id: %s", plan.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_id", plan.ID.ValueString()))
 
 	var eventTypes []string
-	for _, eventType := range plan.EventTypes {
-		eventTypes = append(eventTypes, eventType.ValueString())
+	diags = plan.EventTypes.ElementsAs(ctx, &eventTypes, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	h := map[string]string{}
-	diags = plan.Headers.ElementsAs(ctx, &h, false)
+	headers, diags := expandWebhookHeaders(ctx, plan.Headers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	headers := []Header{}
-	for name, value := range h {
-		headers = append(headers, Header{
-			Name:  name,
-			Value: value,
-		})
-	}
+	ctx = maskWebhookHeaders(ctx, headers)
 
-	config := WebhookConfig{
+	config := longship.WebhookConfig{
 		Name:       plan.Name.ValueString(),
 		OUCode:     plan.OUCode.ValueString(),
 		Enabled:    plan.Enabled.ValueBool(),
@@ -357,7 +456,7 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		URL:        plan.URL.ValueString(),
 	}
 
-	webhook, err := r.client.UpdateWebhook(plan.ID.ValueString(), config)
+	webhook, err := r.client.Webhooks.Update(ctx, plan.ID.ValueString(), config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating webhook",
@@ -371,19 +470,13 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 	plan.OUCode = types.StringValue(webhook.OUCode)
 	plan.Enabled = types.BoolValue(webhook.Enabled)
 	plan.URL = types.StringValue(webhook.URL)
-	plan.Updated = types.StringValue(webhook.Updated)
-	plan.Created = types.StringValue(webhook.Created)
+	plan.Updated = types.StringPointerValue(webhook.Updated)
+	plan.Created = types.StringPointerValue(webhook.Created)
 
-	for idx, eventType := range webhook.EventTypes {
-		plan.EventTypes[idx] = types.StringValue(eventType)
-	}
-
-	m := make(map[string]attr.Value)
-	for _, header := range webhook.Headers {
-		m[header.Name] = types.StringValue(header.Value)
-	}
+	plan.EventTypes, diags = types.SetValueFrom(ctx, types.StringType, webhook.EventTypes)
+	resp.Diagnostics.Append(diags...)
 
-	plan.Headers, diags = types.MapValue(types.StringType, m)
+	plan.Headers, diags = flattenWebhookHeaders(ctx, webhook.Headers, plan.Headers, plan.WriteOnlyHeaders)
 	resp.Diagnostics.Append(diags...)
 
 	// Set state to fully populated data
@@ -392,11 +485,21 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(keepWebhookIdentity(ctx, req.Identity, resp.Identity, r.tenant, webhook.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(verifyWebhook(ctx, r.transport, plan, headers)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 
+	ctx, span := startSpan(ctx, "longship_webhook", "Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state WebhookResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -406,9 +509,10 @@ func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting webhook id: %s", state.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_id", state.ID.ValueString()))
 
 	// Get refreshed webhook value from Longship
-	err := r.client.DeleteWebhook(state.ID.ValueString())
+	err := r.client.Webhooks.Delete(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Longship Webhook",
@@ -418,10 +522,514 @@ func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ImportState imports a webhook either by its unique identifier or by a
+// composite `ou_code/name` identifier.
 func (r *webhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 
-	tflog.Info(ctx, fmt.Sprintf("Importing webhook id: %s", path.Root("id")))
+	ctx, span := startSpan(ctx, "longship_webhook", "ImportState", attribute.String("longship.import_id", req.ID))
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	ouCode, name, found := strings.Cut(req.ID, "/")
+	if !found {
+		tflog.Info(ctx, fmt.Sprintf("Importing webhook id: %s", req.ID))
+
+		// Retrieve import ID or identity and save to id attribute
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(completeWebhookIdentity(ctx, resp.Identity, r.tenant)...)
+		return
+	}
+
+	if ouCode == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected import identifier with format `ou_code/name` or a webhook ID, got: %q", req.ID),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Importing webhook with name %q in OU %q", name, ouCode))
+
+	webhooks, err := r.client.Webhooks.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Longship Webhooks",
+			"Could not read Longship webhooks to resolve import identifier "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	ids := []string{}
+	for _, w := range webhooks {
+		if w.OUCode == ouCode && w.Name == name {
+			ids = append(ids, w.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Webhook Not Found",
+			fmt.Sprintf("No webhook with name %q exists in OU %q.", name, ouCode),
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Ambiguous Import Identifier",
+			fmt.Sprintf("Found %d webhooks with name %q in OU %q, import one of them by ID instead: %s",
+				len(ids), name, ouCode, strings.Join(ids, ", ")),
+		)
+	}
+}
+
+// ConfigValidators returns the validators of rules spanning several
+// attributes, such as the url with allow_insecure_url.
+func (r *webhookResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		webhookURLValidator{},
+		webhookHeadersValidator{},
+	}
+}
+
+// ModifyPlan checks the url against the hosts allowed by the provider, and
+// validates a new or changed ou_code against the organizational units of the
+// tenant, so that both surface during plan instead of apply. Unlike config
+// validation, this runs with the provider configured.
+func (r *webhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// Nothing to validate on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var url types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root("url"), &url)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !url.IsNull() && !url.IsUnknown() {
+		resp.Diagnostics.Append(checkWebhookHost(path.Root("url"), url.ValueString(), r.allowedHosts)...)
+	}
+
+	// Nothing more to validate when validation is disabled.
+	if r.ouCodes == nil {
+		return
+	}
+
+	var ouCode types.String
+	diags = req.Plan.GetAttribute(ctx, path.Root("ou_code"), &ouCode)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || ouCode.IsNull() || ouCode.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var priorOUCode types.String
+		diags = req.State.GetAttribute(ctx, path.Root("ou_code"), &priorOUCode)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || priorOUCode.Equal(ouCode) {
+			return
+		}
+	}
+
+	codes, err := r.ouCodes.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ou_code"),
+			"Unable to Validate Organizational Unit Code",
+			"Could not read Longship organizational units to validate ou_code, it will be validated by the API during apply instead. "+
+				"Set validate_ou_codes to false in the provider configuration to skip this check.\n\n"+
+				"Longship Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, code := range codes {
+		if code == ouCode.ValueString() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("ou_code"),
+		"Unknown Organizational Unit Code",
+		unknownOUCodeDetail(ouCode.ValueString(), codes),
+	)
+}
+
+// ValidateConfig ensures every write-only header refers to a configured header.
+func (r *webhookResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var config WebhookResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Verify != nil && !config.Verify.Timeout.IsNull() && !config.Verify.Timeout.IsUnknown() {
+		if d, err := time.ParseDuration(config.Verify.Timeout.ValueString()); err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("verify").AtName("timeout"),
+				"Invalid Webhook Verification Timeout",
+				fmt.Sprintf("The timeout must be a positive duration such as `10s` or `1m`, got: %q", config.Verify.Timeout.ValueString()),
+			)
+		}
+	}
+
+	if config.WriteOnlyHeaders.IsNull() || config.WriteOnlyHeaders.IsUnknown() ||
+		config.Headers.IsNull() || config.Headers.IsUnknown() {
+		return
+	}
+
+	var writeOnly []types.String
+	diags = config.WriteOnlyHeaders.ElementsAs(ctx, &writeOnly, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := config.Headers.Elements()
+	for _, name := range writeOnly {
+		if name.IsUnknown() {
+			continue
+		}
+
+		if _, ok := headers[name.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("write_only_headers"),
+				"Unknown Write-Only Header",
+				fmt.Sprintf("The write-only header %q is not configured in headers. "+
+					"Every entry of write_only_headers must be the name of a header in headers.", name.ValueString()),
+			)
+		}
+	}
+}
+
+// setWebhookIdentity sets the resource identity of the webhook with id in
+// tenant t, if Terraform supports resource identities. The host and tenant
+// are null when the provider is not configured.
+func setWebhookIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, t *tenant, id string) diag.Diagnostics {
+
+	if identity == nil {
+		return nil
+	}
+
+	model, diags := newWebhookIdentity(ctx, t, id)
+	if diags.HasError() {
+		return diags
+	}
+
+	return identity.Set(ctx, model)
+}
+
+// keepWebhookIdentity sets the resource identity of the webhook with id after
+// a refresh or update. Terraform rejects changes of a stored identity, so the
+// prior identity is kept as is and the host and tenant are only recorded when
+// there is none, such as when the webhook was created by an earlier version
+// of Terraform. Identities imported without host or tenant by an unconfigured
+// provider keep them null.
+func keepWebhookIdentity(ctx context.Context, prior, identity *tfsdk.ResourceIdentity, t *tenant, id string) diag.Diagnostics {
+
+	if identity == nil {
+		return nil
+	}
+
+	if prior != nil && !prior.Raw.IsFullyNull() {
+		identity.Raw = prior.Raw.Copy()
+		return nil
+	}
+
+	return setWebhookIdentity(ctx, identity, t, id)
+}
+
+// completeWebhookIdentity records the host and tenant of t in an identity
+// given to import a webhook, when it does not specify them. The identity may
+// still change during the read following an import.
+func completeWebhookIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, t *tenant) diag.Diagnostics {
+
+	if identity == nil || identity.Raw.IsNull() || t == nil {
+		return nil
+	}
+
+	var model webhookIdentityModel
+	diags := identity.Get(ctx, &model)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, diags := newWebhookIdentity(ctx, t, model.ID.ValueString())
+	if diags.HasError() {
+		return diags
+	}
+
+	if model.Host.IsNull() {
+		model.Host = current.Host
+	}
+
+	if model.Tenant.IsNull() {
+		model.Tenant = current.Tenant
+	}
+
+	return identity.Set(ctx, model)
+}
+
+// newWebhookIdentity returns the resource identity of the webhook with id in
+// tenant t.
+func newWebhookIdentity(ctx context.Context, t *tenant, id string) (webhookIdentityModel, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	model := webhookIdentityModel{
+		Host:   types.StringNull(),
+		Tenant: types.StringNull(),
+		ID:     types.StringValue(id),
+	}
 
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if t == nil {
+		return model, diags
+	}
+
+	fingerprint, err := t.fingerprint(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Determine Longship Tenant",
+			"Could not retrieve the tenant key to identify the tenant of webhook "+id+": "+err.Error(),
+		)
+		return model, diags
+	}
+
+	model.Host = types.StringValue(t.host)
+	model.Tenant = types.StringValue(fingerprint)
+
+	return model, diags
+}
+
+// checkWebhookIdentity returns an error when the prior identity of a webhook
+// records a different host or tenant than the provider is configured for.
+// Identities without host or tenant, such as those imported by an unconfigured
+// provider, match any tenant.
+func checkWebhookIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, t *tenant) diag.Diagnostics {
+
+	if identity == nil || identity.Raw.IsNull() || t == nil {
+		return nil
+	}
+
+	var prior webhookIdentityModel
+	diags := identity.Get(ctx, &prior)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, diags := newWebhookIdentity(ctx, t, prior.ID.ValueString())
+	if diags.HasError() {
+		return diags
+	}
+
+	if !prior.Host.IsNull() && strings.TrimSuffix(prior.Host.ValueString(), "/") != current.Host.ValueString() {
+		diags.AddError(
+			"Webhook Belongs to a Different Longship Host",
+			fmt.Sprintf("Webhook %s was created with the Longship API at %s, but the provider is configured for %s. "+
+				"Configure the provider for the original host, or remove the webhook from the state with `terraform state rm` "+
+				"before managing it with this provider configuration.",
+				prior.ID.ValueString(), prior.Host.ValueString(), current.Host.ValueString()),
+		)
+	}
+
+	if prior.Tenant.IsNull() {
+		return diags
+	}
+
+	if prior.Tenant.ValueString() != current.Tenant.ValueString() {
+		diags.AddError(
+			"Webhook Belongs to a Different Longship Tenant",
+			fmt.Sprintf("Webhook %s was created in the tenant with fingerprint %s, but the provider is configured for the tenant with fingerprint %s. "+
+				"Configure the provider with the original tenant key, or remove the webhook from the state with `terraform state rm` "+
+				"before managing it with this provider configuration.",
+				prior.ID.ValueString(), prior.Tenant.ValueString(), current.Tenant.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// expandWebhookHeaders converts the headers map into its API representation.
+func expandWebhookHeaders(ctx context.Context, headers types.Map) ([]longship.Header, diag.Diagnostics) {
+
+	h := map[string]string{}
+	diags := headers.ElementsAs(ctx, &h, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := []longship.Header{}
+	for name, value := range h {
+		result = append(result, longship.Header{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	return result, diags
+}
+
+// flattenWebhookHeaders converts the API headers into the headers map. The
+// values of write-only headers are carried over from prior, as the API may
+// mask them when the webhook is read back.
+func flattenWebhookHeaders(ctx context.Context, headers []longship.Header, prior types.Map, writeOnly types.Set) (types.Map, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	writeOnlyNames := []string{}
+	if !writeOnly.IsNull() && !writeOnly.IsUnknown() {
+		diags.Append(writeOnly.ElementsAs(ctx, &writeOnlyNames, false)...)
+		if diags.HasError() {
+			return types.MapNull(types.StringType), diags
+		}
+	}
+
+	isWriteOnly := map[string]bool{}
+	for _, name := range writeOnlyNames {
+		isWriteOnly[name] = true
+	}
+
+	m := map[string]attr.Value{}
+	for _, header := range headers {
+		if isWriteOnly[header.Name] {
+			continue
+		}
+		m[header.Name] = types.StringValue(header.Value)
+	}
+
+	priorHeaders := prior.Elements()
+	for name := range isWriteOnly {
+		if value, ok := priorHeaders[name]; ok {
+			m[name] = value
+		}
+	}
+
+	result, d := types.MapValue(types.StringType, m)
+	diags.Append(d...)
+
+	return result, diags
+}
+
+// maskWebhookHeaders masks the header values in all log output of ctx.
+func maskWebhookHeaders(ctx context.Context, headers []longship.Header) context.Context {
+
+	values := []string{}
+	for _, header := range headers {
+		if header.Value != "" {
+			values = append(values, header.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		return ctx
+	}
+
+	return tflog.MaskLogStrings(ctx, values...)
+}
+
+// webhookResourceModelV0 is the state model of schema version 0, in which
+// event_types was stored as an ordered list.
+type webhookResourceModelV0 struct {
+	ID         types.String   `tfsdk:"id"`
+	Name       types.String   `tfsdk:"name"`
+	OUCode     types.String   `tfsdk:"ou_code"`
+	Enabled    types.Bool     `tfsdk:"enabled"`
+	EventTypes []types.String `tfsdk:"event_types"`
+	URL        types.String   `tfsdk:"url"`
+	Headers    types.Map      `tfsdk:"headers"`
+	Created    types.String   `tfsdk:"created"`
+	Updated    types.String   `tfsdk:"updated"`
+}
+
+// UpgradeState migrates prior versions of the webhook state to the current
+// schema version.
+func (r *webhookResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored event_types as a list, which is now a set.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"ou_code": schema.StringAttribute{
+						Required: true,
+					},
+					"enabled": schema.BoolAttribute{
+						Computed: true,
+						Optional: true,
+					},
+					"event_types": schema.ListAttribute{
+						ElementType: types.StringType,
+						Required:    true,
+					},
+					"url": schema.StringAttribute{
+						Required: true,
+					},
+					"headers": schema.MapAttribute{
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"created": schema.StringAttribute{
+						Computed: true,
+					},
+					"updated": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			StateUpgrader: upgradeWebhookResourceStateV0toV1,
+		},
+	}
+}
+
+func upgradeWebhookResourceStateV0toV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+
+	var priorState webhookResourceModelV0
+
+	diags := req.State.Get(ctx, &priorState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Upgrading webhook id %s state from version 0", priorState.ID.ValueString()))
+
+	eventTypes, diags := types.SetValueFrom(ctx, types.StringType, priorState.EventTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgradedState := WebhookResourceModel{
+		ID:         priorState.ID,
+		Name:       priorState.Name,
+		OUCode:     priorState.OUCode,
+		Enabled:    priorState.Enabled,
+		EventTypes: eventTypes,
+		URL:        priorState.URL,
+		Headers:    priorState.Headers,
+		Created:    priorState.Created,
+		Updated:    priorState.Updated,
+
+		WriteOnlyHeaders: types.SetNull(types.StringType),
+	}
+
+	diags = resp.State.Set(ctx, upgradedState)
+	resp.Diagnostics.Append(diags...)
 }
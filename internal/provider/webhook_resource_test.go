@@ -1,9 +1,21 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 func TestAccWebhookResource(t *testing.T) {
@@ -26,7 +38,7 @@ resource "longship_webhook" "test" {
 					resource.TestCheckResourceAttr("longship_webhook.test", "name", "test"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "ou_code", "0000"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "enabled", "false"),
-					resource.TestCheckResourceAttr("longship_webhook.test", "event_types.0", "SESSION_START"),
+					resource.TestCheckTypeSetElemAttr("longship_webhook.test", "event_types.*", "SESSION_START"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "url", "https://example.com"),
 
 					// Verify dynamic values have any value set in the state.
@@ -41,6 +53,13 @@ resource "longship_webhook" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState testing by OU code and name
+			{
+				ResourceName:      "longship_webhook.test",
+				ImportState:       true,
+				ImportStateId:     "0000/test",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -60,7 +79,8 @@ resource "longship_webhook" "test" {
 					resource.TestCheckResourceAttr("longship_webhook.test", "name", "test2"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "ou_code", "0000"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "enabled", "false"),
-					resource.TestCheckResourceAttr("longship_webhook.test", "event_types.1", "SESSION_STOP"),
+					resource.TestCheckResourceAttr("longship_webhook.test", "event_types.#", "2"),
+					resource.TestCheckTypeSetElemAttr("longship_webhook.test", "event_types.*", "SESSION_STOP"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "url", "https://example.com"),
 					resource.TestCheckResourceAttr("longship_webhook.test", "headers.hello", "world"),
 
@@ -74,3 +94,305 @@ resource "longship_webhook" "test" {
 		},
 	})
 }
+
+func TestWebhookResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	upgrader := NewWebhookResource().(*webhookResource).UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+
+	req := fwresource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw: tftypes.NewValue(priorType, map[string]tftypes.Value{
+				"id":      tftypes.NewValue(tftypes.String, "00000000-0000-0000-0000-000000000000"),
+				"name":    tftypes.NewValue(tftypes.String, "test"),
+				"ou_code": tftypes.NewValue(tftypes.String, "0000"),
+				"enabled": tftypes.NewValue(tftypes.Bool, true),
+				"event_types": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "SESSION_STOP"),
+					tftypes.NewValue(tftypes.String, "SESSION_START"),
+				}),
+				"url":     tftypes.NewValue(tftypes.String, "https://example.com"),
+				"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{}),
+				"created": tftypes.NewValue(tftypes.String, "2023-01-01T00:00:00Z"),
+				"updated": tftypes.NewValue(tftypes.String, "2023-01-01T00:00:00Z"),
+			}),
+		},
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	NewWebhookResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state WebhookResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("SESSION_START"),
+		types.StringValue("SESSION_STOP"),
+	})
+
+	if !state.EventTypes.Equal(expected) {
+		t.Errorf("expected event_types %s, got %s", expected, state.EventTypes)
+	}
+
+	if state.ID.ValueString() != "00000000-0000-0000-0000-000000000000" {
+		t.Errorf("expected id to be carried over, got %s", state.ID)
+	}
+}
+
+func TestWebhookResourceReadKeepsIdentityWithoutHostAndTenant(t *testing.T) {
+	ctx := context.Background()
+
+	const id = "00000000-0000-0000-0000-000000000000"
+
+	identitySchema := webhookIdentitySchema(t)
+
+	// Identities imported by an unconfigured provider only record the id.
+	stored := tfsdk.ResourceIdentity{
+		Schema: identitySchema,
+		Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+	}
+	if diags := stored.Set(ctx, webhookIdentityModel{
+		Host:   types.StringNull(),
+		Tenant: types.StringNull(),
+		ID:     types.StringValue(id),
+	}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "` + id + `", "name": "test", "ouCode": "0000", "enabled": true, "eventTypes": ["SESSION_START"], "url": "https://example.com", "headers": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &webhookResource{client: client, tenant: &tenant{
+		host:        server.URL,
+		credentials: longship.StaticCredentials{TenantKey: "tenant", ApplicationKey: "application"},
+	}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, WebhookResourceModel{
+		ID:               types.StringValue(id),
+		Name:             types.StringValue("test"),
+		OUCode:           types.StringValue("0000"),
+		Enabled:          types.BoolValue(true),
+		EventTypes:       types.SetValueMust(types.StringType, []attr.Value{types.StringValue("SESSION_START")}),
+		URL:              types.StringValue("https://example.com"),
+		Headers:          types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		Created:          types.StringNull(),
+		Updated:          types.StringNull(),
+		WriteOnlyHeaders: types.SetNull(types.StringType),
+		AllowInsecureURL: types.BoolValue(false),
+	}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Like the framework, the response starts from the current identity,
+	// which must not change during a refresh.
+	req := fwresource.ReadRequest{
+		State:    state,
+		Identity: &tfsdk.ResourceIdentity{Schema: identitySchema, Raw: stored.Raw.Copy()},
+	}
+	resp := fwresource.ReadResponse{
+		State:    state,
+		Identity: &tfsdk.ResourceIdentity{Schema: identitySchema, Raw: stored.Raw.Copy()},
+	}
+
+	r.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !resp.Identity.Raw.Equal(stored.Raw) {
+		t.Errorf("expected identity %s to be kept, got %s", stored.Raw, resp.Identity.Raw)
+	}
+
+	var refreshed WebhookResourceModel
+	if diags := resp.State.Get(ctx, &refreshed); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The API omits the timestamps of the webhook.
+	if !refreshed.Created.IsNull() || !refreshed.Updated.IsNull() {
+		t.Errorf("expected null created and updated, got %s and %s", refreshed.Created, refreshed.Updated)
+	}
+}
+
+func TestCheckWebhookIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	identitySchema := webhookIdentitySchema(t)
+	identityType := identitySchema.Type().TerraformType(ctx)
+
+	configured := &tenant{
+		host:        "https://api.example.com",
+		credentials: longship.StaticCredentials{TenantKey: "tenant", ApplicationKey: "application"},
+	}
+
+	identity := func(host, tenant any) *tfsdk.ResourceIdentity {
+		return &tfsdk.ResourceIdentity{
+			Schema: identitySchema,
+			Raw: tftypes.NewValue(identityType, map[string]tftypes.Value{
+				"host":   tftypes.NewValue(tftypes.String, host),
+				"tenant": tftypes.NewValue(tftypes.String, tenant),
+				"id":     tftypes.NewValue(tftypes.String, "00000000-0000-0000-0000-000000000000"),
+			}),
+		}
+	}
+
+	testCases := map[string]struct {
+		identity *tfsdk.ResourceIdentity
+		expected []string
+	}{
+		"no identity": {
+			identity: nil,
+		},
+		"null identity": {
+			identity: &tfsdk.ResourceIdentity{
+				Schema: identitySchema,
+				Raw:    tftypes.NewValue(identityType, nil),
+			},
+		},
+		"id only": {
+			identity: identity(nil, nil),
+		},
+		"matching": {
+			identity: identity("https://api.example.com", tenantFingerprint("tenant")),
+		},
+		"matching host with trailing slash": {
+			identity: identity("https://api.example.com/", tenantFingerprint("tenant")),
+		},
+		"different host": {
+			identity: identity("https://api.other.example.com", tenantFingerprint("tenant")),
+			expected: []string{"Webhook Belongs to a Different Longship Host"},
+		},
+		"different tenant": {
+			identity: identity("https://api.example.com", tenantFingerprint("other")),
+			expected: []string{"Webhook Belongs to a Different Longship Tenant"},
+		},
+		"different host and tenant": {
+			identity: identity("https://api.other.example.com", tenantFingerprint("other")),
+			expected: []string{
+				"Webhook Belongs to a Different Longship Host",
+				"Webhook Belongs to a Different Longship Tenant",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkWebhookIdentity(ctx, tc.identity, configured)
+
+			summaries := []string{}
+			for _, d := range diags.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if !slices.Equal(summaries, tc.expected) {
+				t.Errorf("expected errors %v, got %v", tc.expected, summaries)
+			}
+		})
+	}
+}
+
+// webhookIdentitySchema returns the current identity schema of the webhook
+// resource.
+func webhookIdentitySchema(t *testing.T) identityschema.Schema {
+	t.Helper()
+
+	var resp fwresource.IdentitySchemaResponse
+	NewWebhookResource().(*webhookResource).IdentitySchema(context.Background(), fwresource.IdentitySchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.IdentitySchema
+}
+
+func TestFlattenWebhookHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	prior := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"Authorization": types.StringValue("Bearer secret"),
+		"hello":         types.StringValue("world"),
+	})
+
+	testCases := map[string]struct {
+		headers   []longship.Header
+		writeOnly types.Set
+		expected  map[string]attr.Value
+	}{
+		"no write-only headers": {
+			headers: []longship.Header{
+				{Name: "Authorization", Value: "****"},
+				{Name: "hello", Value: "world"},
+			},
+			writeOnly: types.SetNull(types.StringType),
+			expected: map[string]attr.Value{
+				"Authorization": types.StringValue("****"),
+				"hello":         types.StringValue("world"),
+			},
+		},
+		"masked write-only header": {
+			headers: []longship.Header{
+				{Name: "Authorization", Value: "****"},
+				{Name: "hello", Value: "world"},
+			},
+			writeOnly: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Authorization")}),
+			expected: map[string]attr.Value{
+				"Authorization": types.StringValue("Bearer secret"),
+				"hello":         types.StringValue("world"),
+			},
+		},
+		"omitted write-only header": {
+			headers: []longship.Header{
+				{Name: "hello", Value: "world"},
+			},
+			writeOnly: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Authorization")}),
+			expected: map[string]attr.Value{
+				"Authorization": types.StringValue("Bearer secret"),
+				"hello":         types.StringValue("world"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := flattenWebhookHeaders(ctx, tc.headers, prior, tc.writeOnly)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			expected := types.MapValueMust(types.StringType, tc.expected)
+			if !got.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
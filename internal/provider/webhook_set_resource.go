@@ -0,0 +1,784 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+var (
+	_ resource.Resource                     = &webhookSetResource{}
+	_ resource.ResourceWithConfigure        = &webhookSetResource{}
+	_ resource.ResourceWithConfigValidators = &webhookSetResource{}
+	_ resource.ResourceWithModifyPlan       = &webhookSetResource{}
+)
+
+// webhookSetDriftedKey is the private state key of the organizational unit
+// codes whose webhooks no longer match the template.
+const webhookSetDriftedKey = "drifted_ou_codes"
+
+// privateState is the private state of a resource as passed to its
+// operations.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type webhookSetResourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Template   webhookSetTemplateModel `tfsdk:"template"`
+	OUCodes    types.Set               `tfsdk:"ou_codes"`
+	WebhookIDs types.Map               `tfsdk:"webhook_ids"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+}
+
+// webhookSetTemplateModel holds the settings shared by the webhooks of a set.
+type webhookSetTemplateModel struct {
+	Name       types.String `tfsdk:"name"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	EventTypes types.Set    `tfsdk:"event_types"`
+	URL        types.String `tfsdk:"url"`
+	Headers    types.Map    `tfsdk:"headers"`
+
+	AllowInsecureURL types.Bool `tfsdk:"allow_insecure_url"`
+}
+
+// equal reports whether the template has the same webhook settings as other.
+// allow_insecure_url only affects validation and is not compared.
+func (t webhookSetTemplateModel) equal(other webhookSetTemplateModel) bool {
+	return t.Name.Equal(other.Name) &&
+		t.Enabled.Equal(other.Enabled) &&
+		t.EventTypes.Equal(other.EventTypes) &&
+		t.URL.Equal(other.URL) &&
+		t.Headers.Equal(other.Headers)
+}
+
+// config returns the API representation of the webhook of the template in
+// the OU with ouCode.
+func (t webhookSetTemplateModel) config(ctx context.Context, ouCode string) (longship.WebhookConfig, diag.Diagnostics) {
+
+	var eventTypes []string
+	diags := t.EventTypes.ElementsAs(ctx, &eventTypes, false)
+	if diags.HasError() {
+		return longship.WebhookConfig{}, diags
+	}
+
+	headers, d := expandWebhookHeaders(ctx, t.Headers)
+	diags.Append(d...)
+
+	return longship.WebhookConfig{
+		Name:       t.Name.ValueString(),
+		OUCode:     ouCode,
+		Enabled:    t.Enabled.ValueBool(),
+		EventTypes: eventTypes,
+		Headers:    headers,
+		URL:        t.URL.ValueString(),
+	}, diags
+}
+
+func NewWebhookSetResource() resource.Resource {
+	return &webhookSetResource{}
+}
+
+// webhookSetResource manages one webhook per organizational unit from a
+// shared template.
+type webhookSetResource struct {
+	client  *longship.Client
+	ouCodes *organizationalUnitCodes
+
+	// allowedHosts restricts the hosts of webhook urls, any host is allowed
+	// when empty.
+	allowedHosts []string
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *webhookSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieving Longship API client")
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.ouCodes = data.ouCodes
+	r.allowedHosts = data.allowedWebhookHosts
+}
+
+// Metadata returns the resource type name.
+func (r *webhookSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *webhookSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an identical webhook in each of a set of organizational units. " +
+			"An existing webhook with the template name in an organizational unit is an error, " +
+			"unless `adopt_existing` is set to migrate from one `longship_webhook` per organizational unit. " +
+			"Webhooks changed outside of Terraform are updated in place by the next apply, " +
+			"and organizational units whose webhook cannot be created are reported as warnings and retried by the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"template": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The settings of the webhook in every organizational unit.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+						Description: "The name which should be used for the webhooks.",
+					},
+					"enabled": schema.BoolAttribute{
+						Computed:    true,
+						Optional:    true,
+						Description: "Should the webhooks be enabled? Defaults to `true`.",
+						Default:     booldefault.StaticBool(true),
+					},
+					"event_types": schema.SetAttribute{
+						ElementType: types.StringType,
+						Required:    true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(webhookEventTypes...)),
+						},
+						Description: "The event types for which to configure the webhooks. Possible values are `SESSION_START`, `SESSION_UPDATE`, `SESSION_STOP`, `OPERATIONAL_STATUS`, `CONNECTIVITY_STATUS`, `CHARGEPOINT_BOOTED`, and `CDR_CREATED`",
+					},
+					"url": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+						Description: "The URL associated with the webhooks. Must use https unless `allow_insecure_url` is set, and match `allowed_webhook_hosts` of the provider when configured.",
+					},
+					"allow_insecure_url": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Allow an http `url`, e.g. for an endpoint on a private network. Events and header values are then sent unencrypted.",
+					},
+					"headers": schema.MapAttribute{
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Sensitive:   true,
+						Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+						Description: "The HTTP headers to be used by the webhooks. Header values are sensitive and are masked in plan output and logs.",
+					},
+				},
+			},
+			"ou_codes": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "The codes of the organizational units to create the webhook in.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing webhook with the template name in an organizational unit, instead of failing to create a duplicate. The adopted webhook is updated to match the template.",
+			},
+			"webhook_ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The IDs of the webhooks by organizational unit code.",
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators of rules spanning several
+// attributes of the template, such as the url with allow_insecure_url.
+func (r *webhookSetResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		webhookURLValidator{parent: path.Root("template")},
+		webhookHeadersValidator{parent: path.Root("template")},
+	}
+}
+
+// ModifyPlan checks the template url against the hosts allowed by the
+// provider, validates added organizational unit codes, and plans the webhook
+// IDs so that only added organizational units and webhooks which drifted from
+// the template show up in the diff.
+func (r *webhookSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var url types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root("template").AtName("url"), &url)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !url.IsNull() && !url.IsUnknown() {
+		resp.Diagnostics.Append(checkWebhookHost(path.Root("template").AtName("url"), url.ValueString(), r.allowedHosts)...)
+	}
+
+	var ouCodes types.Set
+	diags = req.Plan.GetAttribute(ctx, path.Root("ou_codes"), &ouCodes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || ouCodes.IsUnknown() {
+		return
+	}
+
+	var planned []types.String
+	diags = ouCodes.ElementsAs(ctx, &planned, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var webhookIDs types.Map
+		diags = req.State.GetAttribute(ctx, path.Root("webhook_ids"), &webhookIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		diags = webhookIDs.ElementsAs(ctx, &prior, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	added := []string{}
+	for _, ouCode := range planned {
+		if ouCode.IsUnknown() {
+			return
+		}
+		if _, ok := prior[ouCode.ValueString()]; !ok {
+			added = append(added, ouCode.ValueString())
+		}
+	}
+
+	r.validateOUCodes(ctx, added, &resp.Diagnostics)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	drifted, diags := getDriftedOUCodes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookIDs := map[string]attr.Value{}
+	for _, ouCode := range planned {
+		if id, ok := prior[ouCode.ValueString()]; ok && !slices.Contains(drifted, ouCode.ValueString()) {
+			webhookIDs[ouCode.ValueString()] = types.StringValue(id)
+		} else {
+			webhookIDs[ouCode.ValueString()] = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("webhook_ids"), types.MapValueMust(types.StringType, webhookIDs))...)
+}
+
+// validateOUCodes adds an error for each of codes that does not exist in the
+// tenant, unless validation is disabled.
+func (r *webhookSetResource) validateOUCodes(ctx context.Context, codes []string, diags *diag.Diagnostics) {
+
+	if len(codes) == 0 || r.ouCodes == nil {
+		return
+	}
+
+	known, err := r.ouCodes.Get(ctx)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("ou_codes"),
+			"Unable to Validate Organizational Unit Codes",
+			"Could not read Longship organizational units to validate ou_codes, they will be validated by the API during apply instead. "+
+				"Set validate_ou_codes to false in the provider configuration to skip this check.\n\n"+
+				"Longship Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, code := range codes {
+		if !slices.Contains(known, code) {
+			diags.AddAttributeError(
+				path.Root("ou_codes"),
+				"Unknown Organizational Unit Code",
+				unknownOUCodeDetail(code, known),
+			)
+		}
+	}
+}
+
+// Create creates a webhook in every organizational unit.
+func (r *webhookSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	ctx, span := startSpan(ctx, "longship_webhook_set", "Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var plan webhookSetResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+
+	tflog.Info(ctx, fmt.Sprintf("Creating webhook set %s: %s", plan.ID.ValueString(), plan.Template.Name.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_set_id", plan.ID.ValueString()))
+
+	diags = r.reconcile(ctx, &plan, map[string]string{}, true, nil)
+	if diags.HasError() && len(plan.WebhookIDs.Elements()) == 0 {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// Once some webhooks exist, report the organizational units which failed
+	// as warnings: errors would taint the set, and replacing it would recreate
+	// the webhooks of all organizational units. The failed organizational
+	// units are left out of webhook_ids, so the next plan adds them again.
+	resp.Diagnostics.Append(errorsAsWarnings(diags, "The webhook is created by the next apply.")...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read drops webhooks from the state which no longer exist, and records the
+// organizational units whose webhooks no longer match the template in private
+// state, so that the next apply updates them by ID.
+func (r *webhookSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	ctx, span := startSpan(ctx, "longship_webhook_set", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state webhookSetResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reading webhook set %s", state.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_set_id", state.ID.ValueString()))
+
+	prior := map[string]string{}
+	diags = state.WebhookIDs.ElementsAs(ctx, &prior, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookIDs, drifted, diags := r.refresh(ctx, state.Template, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.WebhookIDs, diags = types.MapValueFrom(ctx, types.StringType, webhookIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(setDriftedOUCodes(ctx, resp.Private, drifted)...)
+}
+
+// refresh reads the webhooks in prior and returns the IDs of those which
+// still exist, and the codes of the organizational units whose webhook no
+// longer matches template. Webhooks which cannot be read are kept.
+func (r *webhookSetResource) refresh(ctx context.Context, template webhookSetTemplateModel, prior map[string]string) (map[string]string, []string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	webhookIDs := map[string]string{}
+	drifted := []string{}
+
+	for _, ouCode := range slices.Sorted(maps.Keys(prior)) {
+		id := prior[ouCode]
+
+		webhook, err := r.client.Webhooks.Get(ctx, id)
+		if longship.IsNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Webhook id %s in OU %s does not exist!", id, ouCode))
+			continue
+		}
+
+		webhookIDs[ouCode] = id
+
+		if err != nil {
+			diags.AddError(
+				"Error Reading Longship Webhook",
+				fmt.Sprintf("Could not read Longship webhook ID %s in OU %q: %s", id, ouCode, err),
+			)
+			continue
+		}
+
+		config, d := template.config(ctx, ouCode)
+		diags.Append(d...)
+		if d.HasError() {
+			return nil, nil, diags
+		}
+
+		if !webhookMatchesConfig(webhook, config) {
+			tflog.Info(ctx, fmt.Sprintf("Webhook id %s in OU %s differs from the template", id, ouCode))
+			drifted = append(drifted, ouCode)
+		}
+	}
+
+	return webhookIDs, drifted, diags
+}
+
+// Update reconciles the webhooks with the planned template and
+// organizational units.
+func (r *webhookSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	ctx, span := startSpan(ctx, "longship_webhook_set", "Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var plan, state webhookSetResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating webhook set %s", state.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_set_id", state.ID.ValueString()))
+
+	prior := map[string]string{}
+	diags = state.WebhookIDs.ElementsAs(ctx, &prior, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	drifted, diags := getDriftedOUCodes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan, prior, !plan.Template.equal(state.Template), drifted)...)
+
+	// Save the reconciled webhooks, also when some organizational units failed.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	// Drift is detected again by the next refresh.
+	resp.Diagnostics.Append(setDriftedOUCodes(ctx, resp.Private, nil)...)
+}
+
+// Delete deletes the webhooks of all organizational units.
+func (r *webhookSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	ctx, span := startSpan(ctx, "longship_webhook_set", "Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state webhookSetResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting webhook set %s", state.ID.ValueString()))
+	span.SetAttributes(attribute.String("longship.webhook_set_id", state.ID.ValueString()))
+
+	prior := map[string]string{}
+	diags = state.WebhookIDs.ElementsAs(ctx, &prior, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, ouCode := range slices.Sorted(maps.Keys(prior)) {
+		resp.Diagnostics.Append(r.deleteWebhook(ctx, ouCode, prior[ouCode])...)
+	}
+}
+
+// reconcile deletes the webhooks in prior of organizational units which are
+// no longer planned, and creates or, when the template changed or they are in
+// drifted, updates the webhooks of the planned organizational units. Existing
+// webhooks with the template name are only adopted when the plan allows it,
+// and are an error otherwise. Failures
+// are reported per organizational unit without aborting the others, and the
+// webhook IDs of the plan are set to the webhooks which exist afterwards.
+func (r *webhookSetResource) reconcile(ctx context.Context, plan *webhookSetResourceModel, prior map[string]string, templateChanged bool, drifted []string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	var planned []string
+	diags.Append(plan.OUCodes.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Strings(planned)
+
+	// Prepare everything which can fail for all organizational units before
+	// changing any of them.
+	configs := map[string]longship.WebhookConfig{}
+	untracked := false
+	for _, ouCode := range planned {
+		config, d := plan.Template.config(ctx, ouCode)
+		diags.Append(d...)
+		configs[ouCode] = config
+
+		if _, ok := prior[ouCode]; !ok {
+			untracked = true
+		}
+	}
+
+	var existing []longship.Webhook
+	if untracked && !diags.HasError() {
+		webhooks, err := r.client.Webhooks.List(ctx)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Longship Webhooks",
+				"Could not read Longship webhooks to find existing webhooks of the set: "+err.Error(),
+			)
+		}
+		existing = webhooks
+	}
+
+	if diags.HasError() {
+		var d diag.Diagnostics
+		plan.WebhookIDs, d = types.MapValueFrom(ctx, types.StringType, prior)
+		diags.Append(d...)
+		return diags
+	}
+
+	webhookIDs := map[string]string{}
+
+	for _, ouCode := range slices.Sorted(maps.Keys(prior)) {
+		if slices.Contains(planned, ouCode) {
+			continue
+		}
+
+		d := r.deleteWebhook(ctx, ouCode, prior[ouCode])
+		if d.HasError() {
+			// Keep tracking the webhook, so that deleting it is retried.
+			webhookIDs[ouCode] = prior[ouCode]
+		}
+		diags.Append(d...)
+	}
+
+	for _, ouCode := range planned {
+		config := configs[ouCode]
+
+		id, tracked := prior[ouCode]
+		if tracked && !templateChanged && !slices.Contains(drifted, ouCode) {
+			webhookIDs[ouCode] = id
+			continue
+		}
+
+		if !tracked {
+			if w := findWebhook(existing, ouCode, config.Name); w != nil {
+				if !plan.AdoptExisting.ValueBool() {
+					diags.AddAttributeError(
+						path.Root("ou_codes"),
+						fmt.Sprintf("Webhook Already Exists in OU %q", ouCode),
+						fmt.Sprintf("Webhook ID %s in organizational unit %q already has the name %q. "+
+							"Set adopt_existing to manage it as part of the set, or rename or delete it.", w.ID, ouCode, config.Name),
+					)
+					continue
+				}
+				id = w.ID
+			}
+		}
+
+		var webhook *longship.WebhookResponse
+		var err error
+		if id != "" {
+			tflog.Info(ctx, fmt.Sprintf("Updating webhook id %s in OU %s", id, ouCode))
+			webhook, err = r.client.Webhooks.Update(ctx, id, config)
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("Creating webhook in OU %s", ouCode))
+			webhook, err = r.client.Webhooks.Create(ctx, config)
+		}
+
+		if err != nil {
+			if tracked {
+				webhookIDs[ouCode] = id
+			}
+			diags.AddAttributeError(
+				path.Root("ou_codes"),
+				fmt.Sprintf("Unable to Reconcile Webhook in OU %q", ouCode),
+				fmt.Sprintf("Could not create or update the webhook of the set in organizational unit %q, the other organizational units are reconciled regardless: %s", ouCode, err),
+			)
+			continue
+		}
+
+		webhookIDs[ouCode] = webhook.ID
+	}
+
+	var d diag.Diagnostics
+	plan.WebhookIDs, d = types.MapValueFrom(ctx, types.StringType, webhookIDs)
+	diags.Append(d...)
+
+	return diags
+}
+
+// findWebhook returns the webhook of webhooks with name in the OU with
+// ouCode, or nil when there is none.
+func findWebhook(webhooks []longship.Webhook, ouCode, name string) *longship.Webhook {
+
+	for i := range webhooks {
+		if webhooks[i].OUCode == ouCode && webhooks[i].Name == name {
+			return &webhooks[i]
+		}
+	}
+
+	return nil
+}
+
+// deleteWebhook deletes the webhook with id in the OU with ouCode, ignoring
+// webhooks which no longer exist.
+func (r *webhookSetResource) deleteWebhook(ctx context.Context, ouCode, id string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting webhook id %s in OU %s", id, ouCode))
+
+	err := r.client.Webhooks.Delete(ctx, id)
+	if err != nil && !longship.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("ou_codes"),
+			fmt.Sprintf("Unable to Delete Webhook in OU %q", ouCode),
+			fmt.Sprintf("Could not delete Longship webhook ID %s in organizational unit %q: %s", id, ouCode, err),
+		)
+	}
+
+	return diags
+}
+
+// webhookMatchesConfig reports whether webhook has the settings of config.
+// Headers are not compared, as the API may mask their values.
+func webhookMatchesConfig(webhook *longship.WebhookResponse, config longship.WebhookConfig) bool {
+
+	eventTypes := slices.Clone(webhook.EventTypes)
+	expected := slices.Clone(config.EventTypes)
+	sort.Strings(eventTypes)
+	sort.Strings(expected)
+
+	return webhook.Name == config.Name &&
+		webhook.OUCode == config.OUCode &&
+		webhook.Enabled == config.Enabled &&
+		webhook.URL == config.URL &&
+		slices.Equal(eventTypes, expected)
+}
+
+// getDriftedOUCodes returns the organizational unit codes whose webhooks were
+// found to differ from the template by the last refresh.
+func getDriftedOUCodes(ctx context.Context, private privateState) ([]string, diag.Diagnostics) {
+
+	value, diags := private.GetKey(ctx, webhookSetDriftedKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	var codes []string
+	if err := json.Unmarshal(value, &codes); err != nil {
+		diags.AddError(
+			"Unable to Read Private State",
+			fmt.Sprintf("Could not decode the drifted organizational units of the webhook set: %s", err),
+		)
+	}
+
+	return codes, diags
+}
+
+// setDriftedOUCodes records codes as the organizational units whose webhooks
+// differ from the template, removing the record when codes is empty.
+func setDriftedOUCodes(ctx context.Context, private privateState, codes []string) diag.Diagnostics {
+
+	if len(codes) == 0 {
+		return private.SetKey(ctx, webhookSetDriftedKey, nil)
+	}
+
+	value, err := json.Marshal(codes)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unable to Write Private State",
+			fmt.Sprintf("Could not encode the drifted organizational units of the webhook set: %s", err),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, webhookSetDriftedKey, value)
+}
+
+// errorsAsWarnings returns diags with errors turned into warnings, appending
+// detail to their details.
+func errorsAsWarnings(diags diag.Diagnostics, detail string) diag.Diagnostics {
+
+	var result diag.Diagnostics
+
+	for _, d := range diags {
+		if d.Severity() != diag.SeverityError {
+			result.Append(d)
+			continue
+		}
+
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			result.AddAttributeWarning(withPath.Path(), d.Summary(), d.Detail()+" "+detail)
+		} else {
+			result.AddWarning(d.Summary(), d.Detail()+" "+detail)
+		}
+	}
+
+	return result
+}
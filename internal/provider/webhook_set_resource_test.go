@@ -0,0 +1,589 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+// fakeWebhooksAPI is an in-memory implementation of the webhook endpoints of
+// the Longship API, which rejects webhooks for the OU code "FAIL".
+type fakeWebhooksAPI struct {
+	mu       sync.Mutex
+	webhooks map[string]longship.WebhookResponse
+	requests []string
+	nextID   int
+}
+
+func (f *fakeWebhooksAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/webhooks"), "/")
+
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, strings.TrimSpace(r.Method+" "+id))
+	}
+
+	var config longship.WebhookConfig
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if config.OUCode == "FAIL" {
+			http.Error(w, "invalid ou code", http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		webhooks := []longship.Webhook{}
+		for _, key := range slices.Sorted(maps.Keys(f.webhooks)) {
+			w := f.webhooks[key]
+			webhooks = append(webhooks, longship.Webhook{ID: w.ID, Name: w.Name, OUCode: w.OUCode})
+		}
+		_ = json.NewEncoder(w).Encode(webhooks)
+	case r.Method == http.MethodGet:
+		webhook, ok := f.webhooks[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(webhook)
+	case r.Method == http.MethodPost:
+		f.nextID++
+		webhook := longship.WebhookResponse{
+			ID:         fmt.Sprintf("new-%d", f.nextID),
+			Name:       config.Name,
+			OUCode:     config.OUCode,
+			Enabled:    config.Enabled,
+			EventTypes: config.EventTypes,
+			URL:        config.URL,
+			Headers:    config.Headers,
+		}
+		f.webhooks[webhook.ID] = webhook
+		_ = json.NewEncoder(w).Encode(webhook)
+	case r.Method == http.MethodPut:
+		if _, ok := f.webhooks[id]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		webhook := longship.WebhookResponse{
+			ID:         id,
+			Name:       config.Name,
+			OUCode:     config.OUCode,
+			Enabled:    config.Enabled,
+			EventTypes: config.EventTypes,
+			URL:        config.URL,
+			Headers:    config.Headers,
+		}
+		f.webhooks[id] = webhook
+		_ = json.NewEncoder(w).Encode(webhook)
+	case r.Method == http.MethodDelete:
+		if id == "locked" {
+			http.Error(w, "webhook is locked", http.StatusConflict)
+			return
+		}
+		delete(f.webhooks, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestWebhookSetResourceReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		existing        map[string]longship.WebhookResponse
+		prior           map[string]string
+		ouCodes         []string
+		templateChanged bool
+		drifted         []string
+		adoptExisting   bool
+		expectedIDs     map[string]string
+		requests        []string
+		errors          []string
+	}{
+		"create": {
+			prior:       map[string]string{},
+			ouCodes:     []string{"OU-B", "OU-A"},
+			expectedIDs: map[string]string{"OU-A": "new-1", "OU-B": "new-2"},
+			requests:    []string{"POST", "POST"},
+		},
+		"add and remove organizational units": {
+			existing: map[string]longship.WebhookResponse{
+				"1": {ID: "1", Name: "test", OUCode: "OU-A"},
+				"2": {ID: "2", Name: "test", OUCode: "OU-B"},
+			},
+			prior:       map[string]string{"OU-A": "1", "OU-B": "2"},
+			ouCodes:     []string{"OU-B", "OU-C"},
+			expectedIDs: map[string]string{"OU-B": "2", "OU-C": "new-1"},
+			requests:    []string{"DELETE 1", "POST"},
+		},
+		"template changed": {
+			existing: map[string]longship.WebhookResponse{
+				"1": {ID: "1", Name: "test", OUCode: "OU-A"},
+				"2": {ID: "2", Name: "test", OUCode: "OU-B"},
+			},
+			prior:           map[string]string{"OU-A": "1", "OU-B": "2"},
+			ouCodes:         []string{"OU-A", "OU-B"},
+			templateChanged: true,
+			expectedIDs:     map[string]string{"OU-A": "1", "OU-B": "2"},
+			requests:        []string{"PUT 1", "PUT 2"},
+		},
+		"drifted webhook": {
+			existing: map[string]longship.WebhookResponse{
+				"1": {ID: "1", Name: "renamed", OUCode: "OU-A"},
+				"2": {ID: "2", Name: "test", OUCode: "OU-B"},
+			},
+			prior:       map[string]string{"OU-A": "1", "OU-B": "2"},
+			ouCodes:     []string{"OU-A", "OU-B"},
+			drifted:     []string{"OU-A"},
+			expectedIDs: map[string]string{"OU-A": "1", "OU-B": "2"},
+			requests:    []string{"PUT 1"},
+		},
+		"adopt existing webhook": {
+			existing: map[string]longship.WebhookResponse{
+				"1": {ID: "1", Name: "test", OUCode: "OU-A"},
+				"2": {ID: "2", Name: "other", OUCode: "OU-B"},
+			},
+			prior:         map[string]string{},
+			ouCodes:       []string{"OU-A", "OU-B"},
+			adoptExisting: true,
+			expectedIDs:   map[string]string{"OU-A": "1", "OU-B": "new-1"},
+			requests:      []string{"PUT 1", "POST"},
+		},
+		"existing webhook without adopt": {
+			existing: map[string]longship.WebhookResponse{
+				"1": {ID: "1", Name: "test", OUCode: "OU-A"},
+				"2": {ID: "2", Name: "other", OUCode: "OU-B"},
+			},
+			prior:       map[string]string{},
+			ouCodes:     []string{"OU-A", "OU-B"},
+			expectedIDs: map[string]string{"OU-B": "new-1"},
+			requests:    []string{"POST"},
+			errors:      []string{`Webhook Already Exists in OU "OU-A"`},
+		},
+		"failing organizational unit": {
+			prior:       map[string]string{},
+			ouCodes:     []string{"FAIL", "OU-A"},
+			expectedIDs: map[string]string{"OU-A": "new-1"},
+			requests:    []string{"POST", "POST"},
+			errors:      []string{`Unable to Reconcile Webhook in OU "FAIL"`},
+		},
+		"failing delete": {
+			existing: map[string]longship.WebhookResponse{
+				"locked": {ID: "locked", Name: "test", OUCode: "OU-A"},
+			},
+			prior:       map[string]string{"OU-A": "locked"},
+			ouCodes:     []string{"OU-B"},
+			expectedIDs: map[string]string{"OU-A": "locked", "OU-B": "new-1"},
+			requests:    []string{"DELETE locked", "POST"},
+			errors:      []string{`Unable to Delete Webhook in OU "OU-A"`},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeWebhooksAPI{webhooks: map[string]longship.WebhookResponse{}}
+			for id, w := range tc.existing {
+				api.webhooks[id] = w
+			}
+
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ouCodes := []attr.Value{}
+			for _, code := range tc.ouCodes {
+				ouCodes = append(ouCodes, types.StringValue(code))
+			}
+
+			plan := webhookSetResourceModel{
+				ID:         types.StringValue("set"),
+				Template:   testWebhookSetTemplate(),
+				OUCodes:    types.SetValueMust(types.StringType, ouCodes),
+				WebhookIDs: types.MapUnknown(types.StringType),
+
+				AdoptExisting: types.BoolValue(tc.adoptExisting),
+			}
+
+			r := &webhookSetResource{client: client}
+			diags := r.reconcile(ctx, &plan, tc.prior, tc.templateChanged, tc.drifted)
+
+			errors := []string{}
+			for _, d := range diags.Errors() {
+				errors = append(errors, d.Summary())
+			}
+
+			if !slices.Equal(errors, tc.errors) {
+				t.Errorf("expected errors %v, got %v: %v", tc.errors, errors, diags)
+			}
+
+			ids := map[string]string{}
+			if diags := plan.WebhookIDs.ElementsAs(ctx, &ids, false); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expectedIDs) {
+				t.Errorf("expected webhook_ids %v, got %v", tc.expectedIDs, ids)
+			}
+
+			if !slices.Equal(api.requests, tc.requests) {
+				t.Errorf("expected requests %v, got %v", tc.requests, api.requests)
+			}
+		})
+	}
+}
+
+func TestWebhookSetResourceRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	api := &fakeWebhooksAPI{webhooks: map[string]longship.WebhookResponse{
+		"1": {ID: "1", Name: "test", OUCode: "OU-A", Enabled: true, EventTypes: []string{"SESSION_START"}, URL: "https://example.com"},
+		"2": {ID: "2", Name: "renamed", OUCode: "OU-B", Enabled: true, EventTypes: []string{"SESSION_START"}, URL: "https://example.com"},
+	}}
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &webhookSetResource{client: client}
+	webhookIDs, drifted, diags := r.refresh(ctx, testWebhookSetTemplate(), map[string]string{"OU-A": "1", "OU-B": "2", "OU-C": "3"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The drifted webhook keeps its ID, so that it is updated rather than
+	// replaced by another webhook with the template name.
+	if expected := map[string]string{"OU-A": "1", "OU-B": "2"}; fmt.Sprint(webhookIDs) != fmt.Sprint(expected) {
+		t.Errorf("expected webhook_ids %v, got %v", expected, webhookIDs)
+	}
+
+	if expected := []string{"OU-B"}; !slices.Equal(drifted, expected) {
+		t.Errorf("expected drifted %v, got %v", expected, drifted)
+	}
+}
+
+// fakePrivateState is an in-memory private state.
+type fakePrivateState map[string][]byte
+
+func (f fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(f, key)
+	} else {
+		f[key] = value
+	}
+	return nil
+}
+
+func TestDriftedOUCodes(t *testing.T) {
+	ctx := context.Background()
+
+	private := fakePrivateState{}
+
+	if diags := setDriftedOUCodes(ctx, private, []string{"OU-A", "OU-B"}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	codes, diags := getDriftedOUCodes(ctx, private)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if expected := []string{"OU-A", "OU-B"}; !slices.Equal(codes, expected) {
+		t.Errorf("expected %v, got %v", expected, codes)
+	}
+
+	if diags := setDriftedOUCodes(ctx, private, nil); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(private) != 0 {
+		t.Errorf("expected the drifted organizational units to be removed, got %v", private)
+	}
+}
+
+func TestWebhookSetResourceCreate(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		ouCodes     []string
+		expectedIDs map[string]string
+		warnings    []string
+		errors      []string
+	}{
+		"failing organizational unit": {
+			ouCodes:     []string{"FAIL", "OU-A"},
+			expectedIDs: map[string]string{"OU-A": "new-1"},
+			warnings:    []string{`Unable to Reconcile Webhook in OU "FAIL"`},
+		},
+		"all organizational units failing": {
+			ouCodes: []string{"FAIL"},
+			errors:  []string{`Unable to Reconcile Webhook in OU "FAIL"`},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeWebhooksAPI{webhooks: map[string]longship.WebhookResponse{}}
+
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			client, err := longship.NewClient(server.URL, longship.WithCredentials("tenant", "application"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &webhookSetResource{client: client}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			ouCodes := []attr.Value{}
+			for _, code := range tc.ouCodes {
+				ouCodes = append(ouCodes, types.StringValue(code))
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			if diags := plan.Set(ctx, webhookSetResourceModel{
+				ID:         types.StringUnknown(),
+				Template:   testWebhookSetTemplate(),
+				OUCodes:    types.SetValueMust(types.StringType, ouCodes),
+				WebhookIDs: types.MapUnknown(types.StringType),
+			}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := fwresource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+
+			warnings := []string{}
+			for _, d := range resp.Diagnostics.Warnings() {
+				warnings = append(warnings, d.Summary())
+			}
+
+			errors := []string{}
+			for _, d := range resp.Diagnostics.Errors() {
+				errors = append(errors, d.Summary())
+			}
+
+			if !slices.Equal(warnings, tc.warnings) || !slices.Equal(errors, tc.errors) {
+				t.Fatalf("expected warnings %v and errors %v, got: %v", tc.warnings, tc.errors, resp.Diagnostics)
+			}
+
+			if tc.expectedIDs == nil {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected no state, got %s", resp.State.Raw)
+				}
+				return
+			}
+
+			var state webhookSetResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			ids := map[string]string{}
+			if diags := state.WebhookIDs.ElementsAs(ctx, &ids, false); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expectedIDs) {
+				t.Errorf("expected webhook_ids %v, got %v", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestWebhookSetResourceConfigValidators(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewWebhookSetResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	templateType := objectType.AttributeTypes["template"].(tftypes.Object)
+
+	testCases := map[string]struct {
+		url           string
+		allowInsecure tftypes.Value
+		headers       map[string]string
+		expected      []string
+	}{
+		"valid": {
+			url:     "https://example.com/events",
+			headers: map[string]string{"Authorization": "Bearer token"},
+		},
+		"http": {
+			url:      "http://example.com/events",
+			expected: []string{"Insecure Webhook URL"},
+		},
+		"http allowed": {
+			url:           "http://10.0.0.1:8080/events",
+			allowInsecure: tftypes.NewValue(tftypes.Bool, true),
+		},
+		"invalid url": {
+			url:      "/events",
+			expected: []string{"Invalid Webhook URL"},
+		},
+		"reserved header": {
+			url:      "https://example.com/events",
+			headers:  map[string]string{"Content-Type": "text/plain"},
+			expected: []string{"Reserved Webhook Header"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			template := map[string]tftypes.Value{}
+			for name, attributeType := range templateType.AttributeTypes {
+				template[name] = tftypes.NewValue(attributeType, nil)
+			}
+			template["url"] = tftypes.NewValue(tftypes.String, tc.url)
+			if tc.allowInsecure.Type() != nil {
+				template["allow_insecure_url"] = tc.allowInsecure
+			}
+			if tc.headers != nil {
+				headers := map[string]tftypes.Value{}
+				for k, v := range tc.headers {
+					headers[k] = tftypes.NewValue(tftypes.String, v)
+				}
+				template["headers"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, headers)
+			}
+
+			attributes := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			attributes["template"] = tftypes.NewValue(templateType, template)
+
+			req := fwresource.ValidateConfigRequest{Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, attributes),
+			}}
+
+			errors := []string{}
+			for _, v := range NewWebhookSetResource().(*webhookSetResource).ConfigValidators(ctx) {
+				var resp fwresource.ValidateConfigResponse
+				v.ValidateResource(ctx, req, &resp)
+
+				for _, d := range resp.Diagnostics.Errors() {
+					errors = append(errors, d.Summary())
+
+					withPath, ok := d.(diag.DiagnosticWithPath)
+					if !ok || !strings.HasPrefix(withPath.Path().String(), "template.") {
+						t.Errorf("expected %q error below template, got %v", d.Summary(), d)
+					}
+				}
+			}
+
+			if !slices.Equal(errors, tc.expected) {
+				t.Errorf("expected errors %v, got %v", tc.expected, errors)
+			}
+		})
+	}
+}
+
+// testWebhookSetTemplate returns the template of the webhook set tests.
+func testWebhookSetTemplate() webhookSetTemplateModel {
+	return webhookSetTemplateModel{
+		Name:       types.StringValue("test"),
+		Enabled:    types.BoolValue(true),
+		EventTypes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("SESSION_START")}),
+		URL:        types.StringValue("https://example.com"),
+		Headers:    types.MapValueMust(types.StringType, map[string]attr.Value{}),
+
+		AllowInsecureURL: types.BoolNull(),
+	}
+}
+
+func TestWebhookMatchesConfig(t *testing.T) {
+	config := longship.WebhookConfig{
+		Name:       "test",
+		OUCode:     "0000",
+		Enabled:    true,
+		EventTypes: []string{"SESSION_START", "SESSION_STOP"},
+		URL:        "https://example.com",
+	}
+
+	testCases := map[string]struct {
+		webhook  longship.WebhookResponse
+		expected bool
+	}{
+		"matching in different event type order": {
+			webhook: longship.WebhookResponse{
+				Name: "test", OUCode: "0000", Enabled: true, URL: "https://example.com",
+				EventTypes: []string{"SESSION_STOP", "SESSION_START"},
+				Headers:    []longship.Header{{Name: "Authorization", Value: "****"}},
+			},
+			expected: true,
+		},
+		"disabled": {
+			webhook: longship.WebhookResponse{
+				Name: "test", OUCode: "0000", Enabled: false, URL: "https://example.com",
+				EventTypes: []string{"SESSION_START", "SESSION_STOP"},
+			},
+			expected: false,
+		},
+		"different url": {
+			webhook: longship.WebhookResponse{
+				Name: "test", OUCode: "0000", Enabled: true, URL: "https://other.example.com",
+				EventTypes: []string{"SESSION_START", "SESSION_STOP"},
+			},
+			expected: false,
+		},
+		"missing event type": {
+			webhook: longship.WebhookResponse{
+				Name: "test", OUCode: "0000", Enabled: true, URL: "https://example.com",
+				EventTypes: []string{"SESSION_START"},
+			},
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := webhookMatchesConfig(&tc.webhook, config); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+const (
+	// defaultWebhookVerifyTimeout is how long the endpoint of a webhook may
+	// take to respond to a test event.
+	defaultWebhookVerifyTimeout = 10 * time.Second
+
+	// defaultWebhookSignatureHeader is the header carrying the signature of
+	// a test event.
+	defaultWebhookSignatureHeader = "X-Longship-Signature"
+
+	// webhookTestEventType is the event type of test events.
+	webhookTestEventType = "WEBHOOK_TEST"
+
+	// webhookVerifyMaxBodyBytes limits how much of the response of a failing
+	// endpoint is included in diagnostics.
+	webhookVerifyMaxBodyBytes = 512
+)
+
+// webhookVerifyModel maps the verify block of a webhook.
+type webhookVerifyModel struct {
+	Timeout         types.String `tfsdk:"timeout"`
+	Secret          types.String `tfsdk:"secret"`
+	SignatureHeader types.String `tfsdk:"signature_header"`
+}
+
+// webhookTestEvent is the payload sent to the endpoint of a webhook to verify
+// it accepts events.
+type webhookTestEvent struct {
+	ID          string `json:"id"`
+	EventType   string `json:"event_type"`
+	Timestamp   string `json:"timestamp"`
+	WebhookID   string `json:"webhook_id"`
+	WebhookName string `json:"webhook_name"`
+	OUCode      string `json:"ou_code"`
+}
+
+// verifyWebhook sends a test event to the endpoint of the webhook in plan
+// with headers through transport, if verification is enabled, and warns when
+// the endpoint does not accept it. The default transport is used when
+// transport is nil.
+func verifyWebhook(ctx context.Context, transport http.RoundTripper, plan WebhookResourceModel, headers []longship.Header) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if plan.Verify == nil {
+		return diags
+	}
+
+	timeout := defaultWebhookVerifyTimeout
+	if !plan.Verify.Timeout.IsNull() {
+		d, err := time.ParseDuration(plan.Verify.Timeout.ValueString())
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(
+				path.Root("verify").AtName("timeout"),
+				"Invalid Webhook Verification Timeout",
+				fmt.Sprintf("The timeout must be a positive duration such as `10s` or `1m`, got: %q", plan.Verify.Timeout.ValueString()),
+			)
+			return diags
+		}
+		timeout = d
+	}
+
+	signatureHeader := defaultWebhookSignatureHeader
+	if !plan.Verify.SignatureHeader.IsNull() {
+		signatureHeader = plan.Verify.SignatureHeader.ValueString()
+	}
+
+	event := webhookTestEvent{
+		ID:          uuid.NewString(),
+		EventType:   webhookTestEventType,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		WebhookID:   plan.ID.ValueString(),
+		WebhookName: plan.Name.ValueString(),
+		OUCode:      plan.OUCode.ValueString(),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Verifying endpoint of webhook id %s with test event %s", event.WebhookID, event.ID))
+
+	httpClient := &http.Client{Timeout: timeout, Transport: transport}
+
+	// Failures are warnings, as the webhook has been saved regardless: an
+	// error would taint a created webhook, but not an updated one.
+	err := sendWebhookTestEvent(ctx, httpClient, plan.URL.ValueString(), headers, event, plan.Verify.Secret.ValueString(), signatureHeader)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("verify"),
+			"Webhook Endpoint Verification Failed",
+			fmt.Sprintf("The endpoint of webhook %s did not accept the test event %s within %s: %s\n\n"+
+				"Check the url and headers of the webhook, such as an authorization header expected by the endpoint. "+
+				"The webhook itself has been saved in Longship.",
+				event.WebhookID, event.ID, timeout, err),
+		)
+	}
+
+	return diags
+}
+
+// sendWebhookTestEvent posts event to url with headers, and returns an error
+// unless the endpoint responds with a 2xx status. When secret is set, the body
+// is signed with HMAC-SHA256 and the signature is sent in signatureHeader as
+// `sha256=<hex digest>`.
+func sendWebhookTestEvent(ctx context.Context, httpClient *http.Client, url string, headers []longship.Header, event webhookTestEvent, secret, signatureHeader string) error {
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	for _, h := range headers {
+		req.Header.Set(h.Name, h.Value)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret != "" {
+		req.Header.Set(signatureHeader, webhookSignature(secret, body))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, webhookVerifyMaxBodyBytes))
+		if s := strings.TrimSpace(string(b)); s != "" {
+			return fmt.Errorf("endpoint responded with %s: %s", resp.Status, s)
+		}
+		return fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// webhookSignature returns the signature of body with secret.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		verify    *webhookVerifyModel
+		status    int
+		body      string
+		hang      bool
+		signature string
+		wantWarn  bool
+	}{
+		"disabled": {
+			verify: nil,
+			status: http.StatusInternalServerError,
+		},
+		"accepted": {
+			verify: &webhookVerifyModel{},
+			status: http.StatusNoContent,
+		},
+		"signed": {
+			verify: &webhookVerifyModel{
+				Secret: types.StringValue("secret"),
+			},
+			status:    http.StatusOK,
+			signature: defaultWebhookSignatureHeader,
+		},
+		"signed with custom header": {
+			verify: &webhookVerifyModel{
+				Secret:          types.StringValue("secret"),
+				SignatureHeader: types.StringValue("X-Signature"),
+			},
+			status:    http.StatusAccepted,
+			signature: "X-Signature",
+		},
+		"rejected": {
+			verify:   &webhookVerifyModel{},
+			status:   http.StatusUnauthorized,
+			body:     "missing token",
+			wantWarn: true,
+		},
+		"redirected": {
+			verify:   &webhookVerifyModel{},
+			status:   http.StatusNotModified,
+			wantWarn: true,
+		},
+		"timeout": {
+			verify: &webhookVerifyModel{
+				Timeout: types.StringValue("50ms"),
+			},
+			hang:     true,
+			wantWarn: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			release := make(chan struct{})
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+
+				if tc.hang {
+					<-release
+					return
+				}
+
+				if got := r.Header.Get("Authorization"); got != "Bearer token" {
+					t.Errorf("expected configured Authorization header, got %q", got)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("unable to read test event: %s", err)
+				}
+
+				var event webhookTestEvent
+				if err := json.Unmarshal(body, &event); err != nil {
+					t.Errorf("unexpected test event %s: %s", body, err)
+				}
+
+				if event.EventType != webhookTestEventType || event.WebhookID != "webhook-id" || event.OUCode != "0000" {
+					t.Errorf("unexpected test event %s", body)
+				}
+
+				if tc.signature != "" {
+					if got, expected := r.Header.Get(tc.signature), webhookSignature("secret", body); got != expected {
+						t.Errorf("expected signature %q in %s, got %q", expected, tc.signature, got)
+					}
+				}
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+			defer close(release)
+
+			plan := WebhookResourceModel{
+				ID:     types.StringValue("webhook-id"),
+				Name:   types.StringValue("test"),
+				OUCode: types.StringValue("0000"),
+				URL:    types.StringValue(server.URL),
+				Verify: tc.verify,
+			}
+
+			headers := []longship.Header{
+				{Name: "Authorization", Value: "Bearer token"},
+			}
+
+			// The test event is sent through the transport of the provider.
+			var sent atomic.Int32
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent.Add(1)
+				return http.DefaultTransport.RoundTrip(req)
+			})
+
+			diags := verifyWebhook(ctx, transport, plan, headers)
+			if diags.HasError() || (diags.WarningsCount() > 0) != tc.wantWarn {
+				t.Fatalf("expected warning %t, got diagnostics: %v", tc.wantWarn, diags)
+			}
+
+			if tc.verify == nil && requests.Load() != 0 {
+				t.Errorf("expected no test event when verification is disabled, got %d", requests.Load())
+			}
+
+			if tc.verify != nil && requests.Load() != 1 {
+				t.Errorf("expected 1 test event, got %d", requests.Load())
+			}
+
+			if sent.Load() != requests.Load() {
+				t.Errorf("expected test events to be sent through the provider transport, got %d of %d", sent.Load(), requests.Load())
+			}
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -17,7 +19,7 @@ var (
 
 // WebhooksDataSource is the data source implementation.
 type WebhooksDataSource struct {
-	client *Client
+	client *longship.Client
 }
 
 type webhooksDataSourceModel struct {
@@ -43,17 +45,17 @@ func (d *WebhooksDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 // Metadata returns the data source type name.
@@ -105,9 +107,12 @@ func (d *WebhooksDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 // Read refreshes the Terraform state with the latest data.
 func (d *WebhooksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	ctx, span := startSpan(ctx, "longship_webhooks", "Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state webhooksDataSourceModel
 
-	webhooks, err := d.client.GetWebhooks()
+	webhooks, err := d.client.Webhooks.List(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Longship Webhooks",
@@ -118,19 +123,7 @@ func (d *WebhooksDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	// Map response body to model
 	for _, webhook := range webhooks {
-		webhookState := WebhookDataSourceModel{
-			ID:      types.StringValue(webhook.ID),
-			Name:    types.StringValue(webhook.Name),
-			Enabled: types.BoolValue(webhook.Enabled),
-			Created: types.StringValue(webhook.Created),
-			Updated: types.StringValue(webhook.Updated),
-		}
-
-		for _, eventType := range webhook.EventTypes {
-			webhookState.EventTypes = append(webhookState.EventTypes, types.StringValue(eventType))
-		}
-
-		state.Webhooks = append(state.Webhooks, webhookState)
+		state.Webhooks = append(state.Webhooks, flattenWebhook(webhook))
 	}
 
 	// Set state
@@ -140,3 +133,22 @@ func (d *WebhooksDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 }
+
+// flattenWebhook maps webhook to its data source model, with null values for
+// fields absent from the API response.
+func flattenWebhook(webhook longship.Webhook) WebhookDataSourceModel {
+
+	webhookState := WebhookDataSourceModel{
+		ID:      types.StringValue(webhook.ID),
+		Name:    types.StringValue(webhook.Name),
+		Enabled: types.BoolValue(webhook.Enabled),
+		Created: types.StringPointerValue(webhook.Created),
+		Updated: types.StringPointerValue(webhook.Updated),
+	}
+
+	for _, eventType := range webhook.EventTypes {
+		webhookState.EventTypes = append(webhookState.EventTypes, types.StringValue(eventType))
+	}
+
+	return webhookState
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cbcoutinho/terraform-provider-longship/longship"
+)
+
+func TestFlattenWebhook(t *testing.T) {
+	testCases := map[string]struct {
+		fixture string
+		created types.String
+		updated types.String
+	}{
+		"all fields": {
+			fixture: `{"id": "1", "name": "test", "enabled": true, "eventTypes": ["SESSION_START"], "created": "2024-01-01T00:00:00Z", "updated": "2024-01-02T00:00:00Z"}`,
+			created: types.StringValue("2024-01-01T00:00:00Z"),
+			updated: types.StringValue("2024-01-02T00:00:00Z"),
+		},
+		"never updated": {
+			fixture: `{"id": "1", "name": "test", "enabled": true, "eventTypes": ["SESSION_START"], "created": "2024-01-01T00:00:00Z", "updated": null}`,
+			created: types.StringValue("2024-01-01T00:00:00Z"),
+			updated: types.StringNull(),
+		},
+		"absent timestamps": {
+			fixture: `{"id": "1", "name": "test", "enabled": true, "eventTypes": ["SESSION_START"]}`,
+			created: types.StringNull(),
+			updated: types.StringNull(),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var webhook longship.Webhook
+			if err := json.Unmarshal([]byte(tc.fixture), &webhook); err != nil {
+				t.Fatal(err)
+			}
+
+			state := flattenWebhook(webhook)
+
+			if !state.Created.Equal(tc.created) {
+				t.Errorf("expected created %s, got %s", tc.created, state.Created)
+			}
+
+			if !state.Updated.Equal(tc.updated) {
+				t.Errorf("expected updated %s, got %s", tc.updated, state.Updated)
+			}
+
+			if state.ID.ValueString() != "1" || len(state.EventTypes) != 1 {
+				t.Errorf("unexpected webhook: %+v", state)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package longship
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache holds the response bodies of list endpoints for a short
+// time, scoped to a single client.
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+
+	// generations counts the invalidations of every collection, so that a
+	// response requested before an invalidation is not cached after it.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:         ttl,
+		entries:     map[string]cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// get returns the cached response body for path, if it has not expired.
+func (c *responseCache) get(path string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[path]
+	if !ok || time.Now().After(entry.expires) {
+		delete(c.entries, path)
+		return nil, false
+	}
+
+	return entry.body, true
+}
+
+// generation returns the number of invalidations of the collections path
+// belongs to. Pass it to set after requesting path.
+func (c *responseCache) generation(path string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generationLocked(path)
+}
+
+func (c *responseCache) generationLocked(path string) uint64 {
+	var generation uint64
+	for collection, n := range c.generations {
+		if inCollection(path, collection) {
+			generation += n
+		}
+	}
+
+	return generation
+}
+
+// set caches body for path, unless a collection of path has been invalidated
+// since generation was taken, in which case body may predate a write.
+func (c *responseCache) set(path string, body []byte, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generationLocked(path) != generation {
+		return
+	}
+
+	c.entries[path] = cacheEntry{
+		body:    body,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// invalidate removes the cached responses of collection and everything
+// below it.
+func (c *responseCache) invalidate(collection string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[collection]++
+
+	for path := range c.entries {
+		if inCollection(path, collection) {
+			delete(c.entries, path)
+		}
+	}
+}
+
+// inCollection reports whether path is collection or below it.
+func inCollection(path, collection string) bool {
+	return path == collection || strings.HasPrefix(path, collection+"/")
+}
@@ -0,0 +1,189 @@
+package longship
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCoalescesInFlightRequests(t *testing.T) {
+	client, mux := setup(t)
+
+	var requests int32
+	release := make(chan struct{})
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		_, _ = w.Write([]byte(`[{"id":"1"}]`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			webhooks, err := client.Webhooks.List(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if len(webhooks) != 1 {
+				t.Errorf("expected 1 webhook, got %d", len(webhooks))
+			}
+		}()
+	}
+
+	// Give all callers the chance to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestClientResponseCache(t *testing.T) {
+	client, mux := setup(t)
+	if err := WithResponseCache(time.Minute)(client); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int32
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Webhooks.List(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected cached list to be requested once, got %d requests", requests)
+	}
+
+	if _, err := client.Webhooks.Create(ctx, WebhookConfig{Name: "test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.Webhooks.List(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected create to invalidate the cached list, got %d requests", requests)
+	}
+}
+
+func TestClientResponseCacheSkipsResponsesPredatingWrites(t *testing.T) {
+	client, mux := setup(t)
+	if err := WithResponseCache(time.Minute)(client); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"2"}`))
+			return
+		}
+
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(started)
+			<-release
+			_, _ = w.Write([]byte(`[{"id":"1"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"1"},{"id":"2"}]`))
+	})
+
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := client.Webhooks.List(ctx); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+
+	// Write to the collection while the list is in flight.
+	<-started
+	if _, err := client.Webhooks.Create(ctx, WebhookConfig{Name: "test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	close(release)
+	<-done
+
+	webhooks, err := client.Webhooks.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(webhooks) != 2 {
+		t.Errorf("expected the list predating the create not to be cached, got %d webhooks", len(webhooks))
+	}
+}
+
+func TestClientCoalescedRequestSurvivesCancelledCaller(t *testing.T) {
+	client, mux := setup(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte(`[{"id":"1"}]`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan error)
+	go func() {
+		_, err := client.Webhooks.List(ctx)
+		first <- err
+	}()
+
+	<-started
+
+	second := make(chan error)
+	go func() {
+		webhooks, err := client.Webhooks.List(context.Background())
+		if err == nil && len(webhooks) != 1 {
+			t.Errorf("expected 1 webhook, got %d", len(webhooks))
+		}
+		second <- err
+	}()
+
+	// Give the second caller the chance to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-first; err != context.Canceled {
+		t.Errorf("expected cancelled caller to return %v, got %v", context.Canceled, err)
+	}
+
+	close(release)
+
+	if err := <-second; err != nil {
+		t.Errorf("expected coalesced caller to succeed, got %s", err)
+	}
+}
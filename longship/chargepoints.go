@@ -0,0 +1,49 @@
+package longship
+
+import "context"
+
+// ChargepointsService handles communication with the chargepoint endpoints of
+// the Longship API.
+type ChargepointsService service
+
+// Chargepoint is a charging station managed by the tenant. Optional fields
+// are nil when the API returns null or omits them.
+type Chargepoint struct {
+	ID                    string  `json:"id"`
+	ChargepointID         string  `json:"chargePointId"`
+	DateDeleted           *string `json:"dateDeleted"`
+	DisplayName           *string `json:"displayName"`
+	RoamingName           *string `json:"roamingName"`
+	ChargeBoxSerialNumber *string `json:"chargeBoxSerialNumber"`
+	ChargepointVendor     *string `json:"chargePointVendor"`
+	Evses                 []Evse  `json:"evses"`
+}
+
+// Evse is an Electric Vehicle Supply Equipment of a chargepoint.
+type Evse struct {
+	EvseID     string      `json:"evse_id"`
+	Connectors []Connector `json:"connectors"`
+}
+
+// Connector is a single connector of an EVSE. Optional fields are nil when
+// the API returns null or omits them.
+type Connector struct {
+	ID                 string  `json:"id"`
+	OperationalStatus  *string `json:"operationalStatus"`
+	Standard           *string `json:"standard"`
+	Format             *string `json:"format"`
+	PowerType          *string `json:"powerType"`
+	MaxVoltage         *int64  `json:"maxVoltage"`
+	MaxAmperage        *int64  `json:"maxAmperage"`
+	MaxElectricalPower *int64  `json:"maxElectricalPower"`
+}
+
+// List returns all chargepoints of the tenant.
+func (s *ChargepointsService) List(ctx context.Context) ([]Chargepoint, error) {
+	chargepoints := []Chargepoint{}
+	if err := s.client.list(ctx, "/v1/chargepoints", &chargepoints); err != nil {
+		return nil, err
+	}
+
+	return chargepoints, nil
+}
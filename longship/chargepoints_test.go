@@ -0,0 +1,34 @@
+package longship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestChargepointsService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/chargepoints", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write([]byte(`[{"id":"1","chargePointId":"CP1","dateDeleted":null,"evses":[{"evse_id":"NL*LSP*E1","connectors":[{"id":"1","maxVoltage":230}]}]}]`))
+	})
+
+	chargepoints, err := client.Chargepoints.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(chargepoints) != 1 || len(chargepoints[0].Evses) != 1 {
+		t.Fatalf("unexpected chargepoints: %+v", chargepoints)
+	}
+
+	if chargepoints[0].DateDeleted != nil {
+		t.Errorf("expected null dateDeleted to decode as nil, got %q", *chargepoints[0].DateDeleted)
+	}
+
+	evse := chargepoints[0].Evses[0]
+	if connector := evse.Connectors[0]; evse.EvseID != "NL*LSP*E1" || connector.MaxVoltage == nil || *connector.MaxVoltage != 230 || connector.MaxAmperage != nil {
+		t.Errorf("unexpected evse: %+v", evse)
+	}
+}
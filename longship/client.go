@@ -0,0 +1,302 @@
+package longship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTimeout   = 30 * time.Second
+	defaultUserAgent = "longship-go"
+
+	// CorrelationIDHeader is the header holding the ID generated for every
+	// request, which Longship support can use to find it in their logs.
+	CorrelationIDHeader = "x-correlation-id"
+)
+
+// Client manages communication with the Longship API.
+type Client struct {
+	hostURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	auth       CredentialsProvider
+	userAgent  string
+	throttle   throttle
+	logger     Logger
+	tracer     trace.Tracer
+
+	// inflight coalesces identical GET requests, and cache holds the
+	// responses of list endpoints when enabled.
+	inflight singleflight.Group
+	cache    *responseCache
+
+	// Services used for talking to the different parts of the Longship API.
+	Webhooks            *WebhooksService
+	Chargepoints        *ChargepointsService
+	OrganizationalUnits *OrganizationalUnitsService
+}
+
+type service struct {
+	client *Client
+}
+
+// NewClient returns a new Longship API client for the API at host, configured
+// with the given options.
+func NewClient(host string, opts ...Option) (*Client, error) {
+
+	c := &Client{
+		hostURL:   strings.TrimSuffix(host, "/"),
+		timeout:   defaultTimeout,
+		userAgent: defaultUserAgent,
+		logger:    nopLogger{},
+		tracer:    otel.Tracer(tracerName),
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: c.timeout}
+	}
+
+	if c.hostURL == "" || c.auth == nil {
+		return nil, fmt.Errorf("misconfigured client, missing host, tenant key, or application key")
+	}
+
+	c.Webhooks = &WebhooksService{client: c}
+	c.Chargepoints = &ChargepointsService{client: c}
+	c.OrganizationalUnits = &OrganizationalUnitsService{client: c}
+
+	return c, nil
+}
+
+// HostURL returns the base URL of the Longship API used by the client.
+func (c *Client) HostURL() string {
+	return c.hostURL
+}
+
+// newRequest creates an API request for the given path relative to the host,
+// encoding body as JSON when it is not nil. Every request gets a new
+// correlation ID, which is kept when the request is retried.
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+
+	var r io.Reader
+	if body != nil {
+		rb, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(rb)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.hostURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(CorrelationIDHeader, uuid.NewString())
+
+	return req, nil
+}
+
+// get sends a GET request for path and decodes the JSON response body into
+// v. Identical requests in flight at the same time are sent only once.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+
+	body, err := c.fetch(ctx, path, false)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// list is like get, but serves the response from the cache of the client
+// when enabled. Use it for collection endpoints only.
+func (c *Client) list(ctx context.Context, path string, v any) error {
+
+	body, err := c.fetch(ctx, path, c.cache != nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// fetch returns the response body of a GET request for path. The request is
+// shared by identical requests in flight, and therefore runs detached from the
+// cancellation of ctx; a caller whose ctx is done stops waiting for it.
+func (c *Client) fetch(ctx context.Context, path string, cached bool) ([]byte, error) {
+
+	var generation uint64
+	if cached {
+		if body, ok := c.cache.get(path); ok {
+			c.logger.Debug(ctx, "Serving response from cache", map[string]any{
+				"path": path,
+			})
+			return body, nil
+		}
+
+		generation = c.cache.generation(path)
+	}
+
+	ch := c.inflight.DoChan(path, func() (any, error) {
+		req, err := c.newRequest(context.WithoutCancel(ctx), http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := c.send(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached {
+			c.cache.set(path, body, generation)
+		}
+
+		return body, nil
+	})
+
+	var result singleflight.Result
+	select {
+	case result = <-ch:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	if result.Shared {
+		c.logger.Debug(ctx, "Coalesced identical in-flight request", map[string]any{
+			"path": path,
+		})
+	}
+
+	body, _ := result.Val.([]byte)
+	return body, nil
+}
+
+// invalidate discards cached and in-flight responses of collection, after it
+// has been written to.
+func (c *Client) invalidate(collection string) {
+	c.inflight.Forget(collection)
+
+	if c.cache != nil {
+		c.cache.invalidate(collection)
+	}
+}
+
+// do sends an API request and decodes the JSON response body into v, unless
+// v is nil.
+func (c *Client) do(req *http.Request, v any) error {
+
+	body, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// send sends an API request and returns the response body. Unexpected status
+// codes are returned as a *StatusError. Requests rejected with 401 or 403 are
+// retried once with refreshed credentials, if the credentials provider of the
+// client supports it.
+func (c *Client) send(req *http.Request) ([]byte, error) {
+
+	body, err := c.attempt(req)
+
+	refreshable, ok := c.auth.(RefreshableCredentialsProvider)
+	if !ok || !IsAuthError(err) {
+		return body, err
+	}
+
+	c.logger.Debug(req.Context(), "Refreshing credentials after authentication failure", map[string]any{
+		"path":           req.URL.Path,
+		"correlation_id": req.Header.Get(CorrelationIDHeader),
+	})
+	refreshable.Invalidate()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.attempt(retry)
+}
+
+// attempt sends a single API request, traced as a span.
+func (c *Client) attempt(req *http.Request) (body []byte, err error) {
+
+	req, span := c.startHTTPSpan(req)
+
+	var res *http.Response
+	defer func() { endHTTPSpan(span, res, err) }()
+
+	creds, err := c.auth.Retrieve(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials: %w", err)
+	}
+
+	req.Header.Set("Ocp-Apim-Subscription-Key", creds.TenantKey)
+	req.Header.Set("x-api-key", creds.ApplicationKey)
+
+	release, err := c.throttle.acquire(req.Context(), c.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	correlationID := req.Header.Get(CorrelationIDHeader)
+
+	res, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w (correlation id: %s)", err, correlationID)
+	}
+	defer res.Body.Close()
+
+	body, err = io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusNoContent {
+		c.logger.Debug(req.Context(), "Longship API request failed", map[string]any{
+			"path":           req.URL.Path,
+			"status_code":    res.StatusCode,
+			"correlation_id": correlationID,
+		})
+		return nil, &StatusError{StatusCode: res.StatusCode, Body: body, CorrelationID: correlationID}
+	}
+
+	return body, nil
+}
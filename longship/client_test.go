@@ -0,0 +1,229 @@
+package longship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// setup returns a client talking to a test server serving mux.
+func setup(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, WithCredentials("tenant", "application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, mux
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+
+	if r.Method != want {
+		t.Errorf("expected request method %s, got %s", want, r.Method)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := map[string]struct {
+		host    string
+		opts    []Option
+		wantErr bool
+	}{
+		"valid": {
+			host: "https://api.longship.io/",
+			opts: []Option{WithCredentials("tenant", "application")},
+		},
+		"missing host": {
+			opts:    []Option{WithCredentials("tenant", "application")},
+			wantErr: true,
+		},
+		"missing credentials": {
+			host:    "https://api.longship.io",
+			wantErr: true,
+		},
+		"nil http client": {
+			host:    "https://api.longship.io",
+			opts:    []Option{WithCredentials("tenant", "application"), WithHTTPClient(nil)},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(tc.host, tc.opts...)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if client.HostURL() != "https://api.longship.io" {
+				t.Errorf("expected trailing slash to be trimmed from host, got %s", client.HostURL())
+			}
+		})
+	}
+}
+
+func TestClientAuthHeaders(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Ocp-Apim-Subscription-Key"); got != "tenant" {
+			t.Errorf("expected Ocp-Apim-Subscription-Key header %q, got %q", "tenant", got)
+		}
+		if got := r.Header.Get("x-api-key"); got != "application" {
+			t.Errorf("expected x-api-key header %q, got %q", "application", got)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	if _, err := client.Webhooks.List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestClientRequestHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, WithCredentials("tenant", "application"), WithUserAgent("test-agent/1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var correlationIDs []string
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "test-agent/1.0" {
+			t.Errorf("expected User-Agent header %q, got %q", "test-agent/1.0", got)
+		}
+		correlationIDs = append(correlationIDs, r.Header.Get(CorrelationIDHeader))
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Webhooks.Create(context.Background(), WebhookConfig{Name: "test"})
+
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) {
+			t.Fatalf("expected StatusError, got %v", err)
+		}
+
+		if statusErr.CorrelationID != correlationIDs[i] {
+			t.Errorf("expected error with correlation id %q, got %q", correlationIDs[i], statusErr.CorrelationID)
+		}
+
+		if !strings.Contains(err.Error(), correlationIDs[i]) {
+			t.Errorf("expected error message to contain correlation id %q, got %q", correlationIDs[i], err)
+		}
+	}
+
+	if correlationIDs[0] == "" || correlationIDs[0] == correlationIDs[1] {
+		t.Errorf("expected a new correlation id for every request, got %q", correlationIDs)
+	}
+}
+
+func TestClientStatusErrors(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		notFound   bool
+	}{
+		"not found": {
+			statusCode: http.StatusNotFound,
+			notFound:   true,
+		},
+		"unauthorized": {
+			statusCode: http.StatusUnauthorized,
+		},
+		"server error": {
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, mux := setup(t)
+
+			mux.HandleFunc("/v1/webhooks/00000000-0000-0000-0000-000000000000", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+			})
+
+			_, err := client.Webhooks.Get(context.Background(), "00000000-0000-0000-0000-000000000000")
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("expected IsNotFound to be %t, got %t for error: %s", tc.notFound, !tc.notFound, err)
+			}
+		})
+	}
+}
+
+// rotatingCredentials returns new credentials after every invalidation.
+type rotatingCredentials struct {
+	generation  int
+	invalidated int
+}
+
+func (c *rotatingCredentials) Retrieve(context.Context) (Credentials, error) {
+	return Credentials{
+		TenantKey:      fmt.Sprintf("tenant-%d", c.generation),
+		ApplicationKey: "application",
+	}, nil
+}
+
+func (c *rotatingCredentials) Invalidate() {
+	c.generation++
+	c.invalidated++
+}
+
+func TestClientRefreshesRejectedCredentials(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	creds := &rotatingCredentials{}
+	client, err := NewClient(server.URL, WithCredentialsProvider(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var correlationIDs []string
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		correlationIDs = append(correlationIDs, r.Header.Get(CorrelationIDHeader))
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "tenant-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"00000000-0000-0000-0000-000000000000"}`))
+	})
+
+	if _, err := client.Webhooks.Create(context.Background(), WebhookConfig{Name: "test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if creds.invalidated != 1 {
+		t.Errorf("expected credentials to be invalidated once, got %d", creds.invalidated)
+	}
+
+	if len(correlationIDs) != 2 || correlationIDs[0] != correlationIDs[1] {
+		t.Errorf("expected the retry to keep the correlation id, got %q", correlationIDs)
+	}
+}
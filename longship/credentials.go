@@ -0,0 +1,39 @@
+package longship
+
+import (
+	"context"
+	"time"
+)
+
+// Credentials authenticate requests against the Longship API.
+type Credentials struct {
+	TenantKey      string
+	ApplicationKey string
+
+	// ExpiresAt is when the credentials expire, or the zero time when they
+	// do not expire.
+	ExpiresAt time.Time
+}
+
+// CredentialsProvider supplies the credentials of a client before every
+// request.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// RefreshableCredentialsProvider is a CredentialsProvider whose credentials
+// can be refreshed. When the API rejects a request with 401 or 403, the client
+// invalidates the credentials and retries the request once.
+type RefreshableCredentialsProvider interface {
+	CredentialsProvider
+	Invalidate()
+}
+
+// StaticCredentials is a CredentialsProvider which always returns the same
+// credentials.
+type StaticCredentials Credentials
+
+// Retrieve returns the static credentials.
+func (s StaticCredentials) Retrieve(context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
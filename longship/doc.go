@@ -0,0 +1,15 @@
+// Package longship provides a client for the Longship.io API, the EV Charging
+// Point Operator (CPO) platform.
+//
+// Create a client with NewClient and use the services grouped by domain:
+//
+//	client, err := longship.NewClient(
+//		"https://api.longship.io",
+//		longship.WithCredentials(tenantKey, applicationKey),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	webhooks, err := client.Webhooks.List(ctx)
+package longship
@@ -0,0 +1,38 @@
+package longship
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// StatusError is returned when the Longship API responds with an unexpected
+// HTTP status code.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+
+	// CorrelationID is the value of the x-correlation-id header of the
+	// failed request.
+	CorrelationID string
+}
+
+func (e *StatusError) Error() string {
+	if e.CorrelationID == "" {
+		return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("status: %d, correlation id: %s, body: %s", e.StatusCode, e.CorrelationID, e.Body)
+}
+
+// IsNotFound reports whether err is a StatusError for a 404 response.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// IsAuthError reports whether err is a StatusError for a 401 or 403 response.
+func IsAuthError(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) &&
+		(statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden)
+}
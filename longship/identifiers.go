@@ -0,0 +1,210 @@
+package longship
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// EvseID is an EVSE ID as defined by ISO 15118-2 and eMI3, such as the
+// EvseID of an Evse, e.g. NL*LSP*E12345*1.
+type EvseID struct {
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country of the
+	// operator, e.g. NL.
+	CountryCode string
+
+	// OperatorID is the three character ID of the charge point operator,
+	// e.g. LSP.
+	OperatorID string
+
+	// PowerOutletID identifies the EVSE within the operator, e.g. 12345*1.
+	PowerOutletID string
+}
+
+// String returns the EVSE ID with separators, e.g. NL*LSP*E12345*1.
+func (e EvseID) String() string {
+	return e.CountryCode + "*" + e.OperatorID + "*E" + e.PowerOutletID
+}
+
+// ParseEvseID parses an EVSE ID with or without `*` separators, e.g.
+// NL*LSP*E12345*1 or NLLSPE12345. The parts are uppercased.
+func ParseEvseID(s string) (EvseID, error) {
+
+	id := strings.ToUpper(strings.TrimSpace(s))
+
+	var e EvseID
+	var outlet string
+
+	if strings.Contains(id, "*") {
+		parts := strings.SplitN(id, "*", 3)
+		if len(parts) != 3 {
+			return EvseID{}, fmt.Errorf("invalid EVSE ID %q: expected <country code>*<operator ID>*E<power outlet ID>, e.g. NL*LSP*E12345", s)
+		}
+		e.CountryCode, e.OperatorID, outlet = parts[0], parts[1], parts[2]
+	} else {
+		if len(id) < 7 {
+			return EvseID{}, fmt.Errorf("invalid EVSE ID %q: expected <country code><operator ID>E<power outlet ID>, e.g. NLLSPE12345", s)
+		}
+		e.CountryCode, e.OperatorID, outlet = id[:2], id[2:5], id[5:]
+	}
+
+	if !strings.HasPrefix(outlet, "E") {
+		return EvseID{}, fmt.Errorf("invalid EVSE ID %q: expected ID type E before the power outlet ID, got %q", s, outlet)
+	}
+	e.PowerOutletID = outlet[1:]
+
+	if err := e.validate(); err != nil {
+		return EvseID{}, fmt.Errorf("invalid EVSE ID %q: %w", s, err)
+	}
+
+	return e, nil
+}
+
+// FormatEvseID returns the EVSE ID of the given parts with separators, e.g.
+// NL*LSP*E12345*1. The parts are uppercased.
+func FormatEvseID(countryCode, operatorID, powerOutletID string) (string, error) {
+
+	e := EvseID{
+		CountryCode:   strings.ToUpper(countryCode),
+		OperatorID:    strings.ToUpper(operatorID),
+		PowerOutletID: strings.ToUpper(powerOutletID),
+	}
+
+	if err := e.validate(); err != nil {
+		return "", err
+	}
+
+	return e.String(), nil
+}
+
+func (e EvseID) validate() error {
+
+	if len(e.CountryCode) != 2 || !isAlpha(e.CountryCode) {
+		return fmt.Errorf("country code must be two letters, got %q", e.CountryCode)
+	}
+
+	if len(e.OperatorID) != 3 || !isAlphanumeric(e.OperatorID) {
+		return fmt.Errorf("operator ID must be three letters or digits, got %q", e.OperatorID)
+	}
+
+	if len(e.PowerOutletID) == 0 || len(e.PowerOutletID) > 31 ||
+		!isAlphanumeric(strings.ReplaceAll(e.PowerOutletID, "*", "")) || e.PowerOutletID[0] == '*' {
+		return fmt.Errorf("power outlet ID must be 1 to 31 letters, digits or * separators, starting with a letter or digit, got %q", e.PowerOutletID)
+	}
+
+	return nil
+}
+
+// ValidateEMAID checks the structure of an e-mobility account identifier
+// (eMAID, contract ID) as defined by ISO 15118-2 and eMI3, e.g.
+// NL-TNM-012345678-9: a country code, a provider ID, an instance of nine
+// letters or digits and an optional check character, with or without `-`
+// separators. The check character itself is not verified.
+func ValidateEMAID(s string) error {
+
+	id := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+
+	if len(id) != 14 && len(id) != 15 {
+		return fmt.Errorf("invalid eMAID %q: expected 14 or 15 letters or digits without separators, got %d", s, len(id))
+	}
+
+	if !isAlpha(id[:2]) {
+		return fmt.Errorf("invalid eMAID %q: country code must be two letters, got %q", s, id[:2])
+	}
+
+	if !isAlphanumeric(id[2:5]) {
+		return fmt.Errorf("invalid eMAID %q: provider ID must be three letters or digits, got %q", s, id[2:5])
+	}
+
+	if !isAlphanumeric(id[5:]) {
+		return fmt.Errorf("invalid eMAID %q: instance and check character must be letters or digits, got %q", s, id[5:])
+	}
+
+	return nil
+}
+
+// ValidateIBAN checks an International Bank Account Number, such as the IBAN
+// of FinancialDetails, including its check digits. Spaces are ignored.
+func ValidateIBAN(s string) error {
+
+	iban := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+
+	if len(iban) < 15 || len(iban) > 34 {
+		return fmt.Errorf("invalid IBAN %q: expected 15 to 34 characters, got %d", s, len(iban))
+	}
+
+	if !isAlpha(iban[:2]) {
+		return fmt.Errorf("invalid IBAN %q: must start with a two letter country code, got %q", s, iban[:2])
+	}
+
+	if !isNumeric(iban[2:4]) {
+		return fmt.Errorf("invalid IBAN %q: country code must be followed by two check digits, got %q", s, iban[2:4])
+	}
+
+	if !isAlphanumeric(iban[4:]) {
+		return fmt.Errorf("invalid IBAN %q: account number must only contain letters and digits", s)
+	}
+
+	// Move the country code and check digits to the end, replace letters by
+	// numbers (A = 10, ..., Z = 35) and verify the remainder modulo 97.
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return fmt.Errorf("invalid IBAN %q: check digits do not match", s)
+	}
+
+	return nil
+}
+
+// NormalizeOUCode trims surrounding whitespace from an organizational unit
+// code. The case is kept, as codes are compared exactly.
+func NormalizeOUCode(s string) (string, error) {
+
+	code := strings.TrimSpace(s)
+
+	if code == "" {
+		return "", fmt.Errorf("invalid OU code %q: must not be empty", s)
+	}
+
+	if strings.IndexFunc(code, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("invalid OU code %q: must not contain whitespace", s)
+	}
+
+	return code, nil
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
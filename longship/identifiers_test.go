@@ -0,0 +1,212 @@
+package longship
+
+import "testing"
+
+func TestParseEvseID(t *testing.T) {
+	testCases := map[string]struct {
+		id       string
+		expected EvseID
+		wantErr  bool
+	}{
+		"with separators": {
+			id:       "NL*LSP*E12345*1",
+			expected: EvseID{CountryCode: "NL", OperatorID: "LSP", PowerOutletID: "12345*1"},
+		},
+		"without separators": {
+			id:       "NLLSPE12345",
+			expected: EvseID{CountryCode: "NL", OperatorID: "LSP", PowerOutletID: "12345"},
+		},
+		"lowercase": {
+			id:       " de*abc*e1 ",
+			expected: EvseID{CountryCode: "DE", OperatorID: "ABC", PowerOutletID: "1"},
+		},
+		"missing id type": {
+			id:      "NL*LSP*12345",
+			wantErr: true,
+		},
+		"numeric country code": {
+			id:      "31*LSP*E12345",
+			wantErr: true,
+		},
+		"short operator id": {
+			id:      "NL*LS*E12345",
+			wantErr: true,
+		},
+		"empty power outlet id": {
+			id:      "NL*LSP*E",
+			wantErr: true,
+		},
+		"too short": {
+			id:      "NLLSP",
+			wantErr: true,
+		},
+		"invalid characters": {
+			id:      "NL*LSP*E123-45",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			evseID, err := ParseEvseID(tc.id)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if evseID != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, evseID)
+			}
+		})
+	}
+}
+
+func TestFormatEvseID(t *testing.T) {
+	id, err := FormatEvseID("nl", "lsp", "12345*1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if id != "NL*LSP*E12345*1" {
+		t.Errorf("expected %q, got %q", "NL*LSP*E12345*1", id)
+	}
+
+	if _, err := FormatEvseID("NLD", "LSP", "1"); err == nil {
+		t.Error("expected error for three letter country code, got none")
+	}
+}
+
+func TestValidateEMAID(t *testing.T) {
+	testCases := map[string]struct {
+		emaid   string
+		wantErr bool
+	}{
+		"with separators and check character": {
+			emaid: "NL-TNM-012345678-9",
+		},
+		"without separators": {
+			emaid: "NLTNM012345678",
+		},
+		"lowercase": {
+			emaid: "de-8aa-1a2b3c4d5-x",
+		},
+		"too short": {
+			emaid:   "NL-TNM-0123",
+			wantErr: true,
+		},
+		"numeric country code": {
+			emaid:   "31-TNM-012345678-9",
+			wantErr: true,
+		},
+		"invalid characters": {
+			emaid:   "NL-TNM-01234567*-9",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateEMAID(tc.emaid)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	testCases := map[string]struct {
+		iban    string
+		wantErr bool
+	}{
+		"valid": {
+			iban: "NL91ABNA0417164300",
+		},
+		"with spaces": {
+			iban: "DE89 3704 0044 0532 0130 00",
+		},
+		"lowercase": {
+			iban: "gb82west12345698765432",
+		},
+		"wrong check digits": {
+			iban:    "NL92ABNA0417164300",
+			wantErr: true,
+		},
+		"too short": {
+			iban:    "NL91ABNA",
+			wantErr: true,
+		},
+		"missing country code": {
+			iban:    "9191ABNA0417164300",
+			wantErr: true,
+		},
+		"invalid characters": {
+			iban:    "NL91ABNA-417164300",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateIBAN(tc.iban)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeOUCode(t *testing.T) {
+	testCases := map[string]struct {
+		code     string
+		expected string
+		wantErr  bool
+	}{
+		"already normalized": {
+			code:     "NL-LSP-01",
+			expected: "NL-LSP-01",
+		},
+		"surrounding whitespace": {
+			code:     "\tnl-lsp-01 \n",
+			expected: "nl-lsp-01",
+		},
+		"empty": {
+			code:    "  ",
+			wantErr: true,
+		},
+		"inner whitespace": {
+			code:    "NL LSP",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeOUCode(tc.code)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package longship
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// throttle bounds the rate and concurrency of the requests sent by a client.
+// The zero value does not limit requests.
+type throttle struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+// acquire blocks until a request may be sent, or ctx is done. The returned
+// function must be called once the request has finished.
+func (t *throttle) acquire(ctx context.Context, logger Logger) (func(), error) {
+
+	if t.limiter != nil {
+		start := time.Now()
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		if delay := time.Since(start); delay > time.Millisecond {
+			logger.Debug(ctx, "Request delayed by client-side rate limiter", map[string]any{
+				"delay_ms": delay.Milliseconds(),
+			})
+		}
+	}
+
+	if t.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case t.inFlight <- struct{}{}:
+		return func() { <-t.inFlight }, nil
+	default:
+	}
+
+	start := time.Now()
+	select {
+	case t.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	logger.Debug(ctx, "Request delayed by maximum number of concurrent requests", map[string]any{
+		"delay_ms":                time.Since(start).Milliseconds(),
+		"max_concurrent_requests": cap(t.inFlight),
+	})
+
+	return func() { <-t.inFlight }, nil
+}
@@ -0,0 +1,14 @@
+package longship
+
+import "context"
+
+// Logger receives diagnostic messages from the client. Fields hold structured
+// context for the message.
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields map[string]any)
+}
+
+// nopLogger discards all messages.
+type nopLogger struct{}
+
+func (nopLogger) Debug(context.Context, string, map[string]any) {}
@@ -0,0 +1,135 @@
+package longship
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// Option configures a Client.
+type Option func(*Client) error
+
+// WithCredentials sets the tenant key and application key used to
+// authenticate against the Longship API.
+func WithCredentials(tenantKey, applicationKey string) Option {
+	return func(c *Client) error {
+		if tenantKey == "" || applicationKey == "" {
+			return fmt.Errorf("misconfigured client, missing tenant key or application key")
+		}
+		c.auth = StaticCredentials{
+			TenantKey:      tenantKey,
+			ApplicationKey: applicationKey,
+		}
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests. Defaults to
+// longship-go.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		if userAgent == "" {
+			return fmt.Errorf("user agent must not be empty")
+		}
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithCredentialsProvider sets the provider supplying the credentials used to
+// authenticate against the Longship API, e.g. from a secrets manager.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(c *Client) error {
+		if provider == nil {
+			return fmt.Errorf("credentials provider must not be nil")
+		}
+		c.auth = provider
+		return nil
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("http client must not be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of requests sent by the client. Defaults to 30
+// seconds, and is ignored when WithHTTPClient is used.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout must not be negative, got: %s", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests on average,
+// using a token bucket shared by all requests of the client.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("requests per second must be positive, got: %v", requestsPerSecond)
+		}
+		c.throttle.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests the client has in
+// flight at the same time.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("max concurrent requests must be positive, got: %d", n)
+		}
+		c.throttle.inFlight = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// WithLogger sets the logger receiving diagnostic messages from the client,
+// e.g. when a request is delayed by the rate limiter.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		if logger == nil {
+			return fmt.Errorf("logger must not be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider used to trace
+// every HTTP request of the client. Defaults to the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) error {
+		if tp == nil {
+			return fmt.Errorf("tracer provider must not be nil")
+		}
+		c.tracer = tp.Tracer(tracerName)
+		return nil
+	}
+}
+
+// WithResponseCache caches the responses of list endpoints for ttl. Writes
+// through the client invalidate the cached responses of their collection.
+func WithResponseCache(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("cache ttl must be positive, got: %s", ttl)
+		}
+		c.cache = newResponseCache(ttl)
+		return nil
+	}
+}
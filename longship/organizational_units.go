@@ -0,0 +1,53 @@
+package longship
+
+import "context"
+
+// OrganizationalUnitsService handles communication with the organizational
+// unit endpoints of the Longship API.
+type OrganizationalUnitsService service
+
+// OrganizationalUnit is a node in the organizational hierarchy of the tenant.
+// Optional fields are nil when the API returns null or omits them.
+type OrganizationalUnit struct {
+	ID                        string            `json:"id"`
+	ParentID                  *string           `json:"parentId"`
+	Name                      string            `json:"name"`
+	Code                      string            `json:"code"`
+	ExternalReference         *string           `json:"external_reference"`
+	GridOwnerReference        *string           `json:"grid_owner_reference"`
+	TenantReference           *string           `json:"tenant_reference"`
+	CustomerReference         *string           `json:"customer_reference"`
+	Address                   *string           `json:"address"`
+	State                     *string           `json:"state"`
+	Country                   *string           `json:"country"`
+	City                      *string           `json:"city"`
+	HouseNumber               *string           `json:"house_number"`
+	PostalCode                *string           `json:"postal_code"`
+	HotlinePhoneNumber        *string           `json:"hotline_phone_number"`
+	CompanyEmail              *string           `json:"company_email"`
+	PrimaryContactPerson      *string           `json:"primary_contact_person"`
+	PrimaryContactPersonEmail *string           `json:"primary_contact_person_email"`
+	DirectPaymentProfileId    *string           `json:"direct_payment_profile_id"`
+	MspOuID                   *string           `json:"msp_ou_id"`
+	MspOuName                 *string           `json:"msp_ou_name"`
+	MspOuCode                 *string           `json:"msp_ou_code"`
+	MspExternalID             *string           `json:"msp_external_id"`
+	FinancialDetails          *FinancialDetails `json:"financialDetails"`
+}
+
+// FinancialDetails holds the bank details of an organizational unit.
+type FinancialDetails struct {
+	BeneficiaryName *string `json:"beneficiaryName"`
+	IBAN            *string `json:"iban"`
+	BIC             *string `json:"bic"`
+}
+
+// List returns all organizational units of the tenant.
+func (s *OrganizationalUnitsService) List(ctx context.Context) ([]OrganizationalUnit, error) {
+	organizationalUnits := []OrganizationalUnit{}
+	if err := s.client.list(ctx, "/v1/organizationalunits", &organizationalUnits); err != nil {
+		return nil, err
+	}
+
+	return organizationalUnits, nil
+}
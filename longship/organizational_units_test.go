@@ -0,0 +1,34 @@
+package longship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestOrganizationalUnitsService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/organizationalunits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write([]byte(`[{"id":"1","parentId":null,"code":"0000","name":"Root","financialDetails":{"iban":"NL91ABNA0417164300"}}]`))
+	})
+
+	organizationalUnits, err := client.OrganizationalUnits.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(organizationalUnits) != 1 {
+		t.Fatalf("expected 1 organizational unit, got %d", len(organizationalUnits))
+	}
+
+	ou := organizationalUnits[0]
+	if ou.Code != "0000" || ou.ParentID != nil {
+		t.Errorf("unexpected organizational unit: %+v", ou)
+	}
+
+	if ou.FinancialDetails == nil || ou.FinancialDetails.IBAN == nil || *ou.FinancialDetails.IBAN != "NL91ABNA0417164300" {
+		t.Errorf("unexpected financial details: %+v", ou.FinancialDetails)
+	}
+}
@@ -0,0 +1,49 @@
+package longship
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the client.
+const tracerName = "github.com/cbcoutinho/terraform-provider-longship/longship"
+
+// startHTTPSpan starts a client span for req and injects its trace context
+// into the request headers. End the span with endHTTPSpan.
+func (c *Client) startHTTPSpan(req *http.Request) (*http.Request, trace.Span) {
+
+	ctx, span := c.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+			attribute.String("longship.correlation_id", req.Header.Get(CorrelationIDHeader)),
+		),
+	)
+
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, span
+}
+
+// endHTTPSpan records the outcome of a request on span and ends it. res is
+// nil when the request failed without a response.
+func endHTTPSpan(span trace.Span, res *http.Response, err error) {
+
+	if res != nil {
+		span.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
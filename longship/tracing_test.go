@@ -0,0 +1,73 @@
+package longship
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClientTracing(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		status     codes.Code
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+			status:     codes.Unset,
+		},
+		"error": {
+			statusCode: http.StatusInternalServerError,
+			status:     codes.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			t.Cleanup(server.Close)
+
+			exporter := tracetest.NewInMemoryExporter()
+			client, err := NewClient(server.URL,
+				WithCredentials("tenant", "application"),
+				WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = client.Webhooks.List(context.Background())
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("expected one span, got %d", len(spans))
+			}
+
+			span := spans[0]
+			if span.Name != "HTTP GET" {
+				t.Errorf("expected span name %q, got %q", "HTTP GET", span.Name)
+			}
+
+			if span.Status.Code != tc.status {
+				t.Errorf("expected span status %s, got %s", tc.status, span.Status.Code)
+			}
+
+			attributes := attribute.NewSet(span.Attributes...)
+			if v, ok := attributes.Value("http.status_code"); !ok || v.AsInt64() != int64(tc.statusCode) {
+				t.Errorf("expected http.status_code attribute %d, got %v", tc.statusCode, v)
+			}
+
+			if v, ok := attributes.Value("longship.correlation_id"); !ok || v.AsString() == "" {
+				t.Error("expected longship.correlation_id attribute")
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package longship
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const webhooksPath = "/v1/webhooks"
+
+// WebhooksService handles communication with the webhook endpoints of the
+// Longship API.
+type WebhooksService service
+
+// Webhook is a webhook as returned when listing webhooks. Created and Updated
+// are nil when the API returns null or omits them.
+type Webhook struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	OUCode     string   `json:"ouCode"`
+	Enabled    bool     `json:"enabled"`
+	EventTypes []string `json:"eventTypes"`
+	URL        string   `json:"url"`
+	Created    *string  `json:"created"`
+	Updated    *string  `json:"updated"`
+}
+
+// WebhookResponse is a single webhook including its headers. Created and
+// Updated are nil when the API returns null or omits them.
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	OUCode     string   `json:"ouCode"`
+	Enabled    bool     `json:"enabled"`
+	EventTypes []string `json:"eventTypes"`
+	URL        string   `json:"url"`
+	Headers    []Header `json:"headers"`
+	Created    *string  `json:"created"`
+	Updated    *string  `json:"updated"`
+}
+
+// WebhookConfig is the request body to create or update a webhook.
+type WebhookConfig struct {
+	Name       string   `json:"name"`
+	OUCode     string   `json:"ouCode"`
+	Enabled    bool     `json:"enabled"`
+	EventTypes []string `json:"eventTypes"`
+	Headers    []Header `json:"headers"`
+	URL        string   `json:"url"`
+}
+
+// Header is an HTTP header sent along with webhook deliveries.
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookDelivery is an attempt to deliver an event to the endpoint of a
+// webhook.
+type WebhookDelivery struct {
+	ID         string `json:"id"`
+	WebhookID  string `json:"webhookId"`
+	EventType  string `json:"eventType"`
+	Timestamp  string `json:"timestamp"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"statusCode"`
+	LatencyMs  int64  `json:"latencyMs"`
+	Error      string `json:"error"`
+}
+
+// WebhookDeliveriesOptions filters the deliveries of a webhook. Zero values
+// do not filter.
+type WebhookDeliveriesOptions struct {
+	From      time.Time
+	To        time.Time
+	EventType string
+	Success   *bool
+}
+
+// query encodes the options as query string.
+func (o WebhookDeliveriesOptions) query() string {
+	q := url.Values{}
+	if !o.From.IsZero() {
+		q.Set("from", o.From.UTC().Format(time.RFC3339))
+	}
+	if !o.To.IsZero() {
+		q.Set("to", o.To.UTC().Format(time.RFC3339))
+	}
+	if o.EventType != "" {
+		q.Set("eventType", o.EventType)
+	}
+	if o.Success != nil {
+		q.Set("success", strconv.FormatBool(*o.Success))
+	}
+	return q.Encode()
+}
+
+// matches reports whether delivery passes the event type and success
+// filters of the options.
+func (o WebhookDeliveriesOptions) matches(delivery WebhookDelivery) bool {
+	if o.EventType != "" && delivery.EventType != o.EventType {
+		return false
+	}
+	if o.Success != nil && delivery.Success != *o.Success {
+		return false
+	}
+	return true
+}
+
+// List returns all webhooks of the tenant.
+func (s *WebhooksService) List(ctx context.Context) ([]Webhook, error) {
+	webhooks := []Webhook{}
+	if err := s.client.list(ctx, webhooksPath, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Get returns the webhook with the given id.
+func (s *WebhooksService) Get(ctx context.Context, id string) (*WebhookResponse, error) {
+	webhook := WebhookResponse{}
+	if err := s.client.get(ctx, webhooksPath+"/"+url.PathEscape(id), &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// ListDeliveries returns the delivery attempts of the webhook with the given
+// id matching opts. The event type and success filters are also applied to
+// the response, in case the API ignores them. Deliveries are never served
+// from the response cache.
+func (s *WebhooksService) ListDeliveries(ctx context.Context, id string, opts WebhookDeliveriesOptions) ([]WebhookDelivery, error) {
+	path := webhooksPath + "/" + url.PathEscape(id) + "/deliveries"
+	if q := opts.query(); q != "" {
+		path += "?" + q
+	}
+
+	deliveries := []WebhookDelivery{}
+	if err := s.client.get(ctx, path, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(deliveries, func(d WebhookDelivery) bool { return !opts.matches(d) }), nil
+}
+
+// Create creates a new webhook.
+func (s *WebhooksService) Create(ctx context.Context, webhook WebhookConfig) (*WebhookResponse, error) {
+	req, err := s.client.newRequest(ctx, http.MethodPost, webhooksPath, webhook)
+	if err != nil {
+		return nil, err
+	}
+	defer s.client.invalidate(webhooksPath)
+
+	newWebhook := WebhookResponse{}
+	if err := s.client.do(req, &newWebhook); err != nil {
+		return nil, err
+	}
+
+	return &newWebhook, nil
+}
+
+// Update replaces the webhook with the given id.
+func (s *WebhooksService) Update(ctx context.Context, id string, webhook WebhookConfig) (*WebhookResponse, error) {
+	req, err := s.client.newRequest(ctx, http.MethodPut, webhooksPath+"/"+url.PathEscape(id), webhook)
+	if err != nil {
+		return nil, err
+	}
+	defer s.client.invalidate(webhooksPath)
+
+	newWebhook := WebhookResponse{}
+	if err := s.client.do(req, &newWebhook); err != nil {
+		return nil, err
+	}
+
+	return &newWebhook, nil
+}
+
+// Delete deletes the webhook with the given id.
+func (s *WebhooksService) Delete(ctx context.Context, id string) error {
+	req, err := s.client.newRequest(ctx, http.MethodDelete, webhooksPath+"/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	defer s.client.invalidate(webhooksPath)
+
+	return s.client.do(req, nil)
+}
@@ -0,0 +1,188 @@
+package longship
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhooksService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write([]byte(`[{"id":"1","name":"test","ouCode":"0000","enabled":true,"eventTypes":["SESSION_START"],"url":"https://example.com"}]`))
+	})
+
+	webhooks, err := client.Webhooks.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []Webhook{{
+		ID:         "1",
+		Name:       "test",
+		OUCode:     "0000",
+		Enabled:    true,
+		EventTypes: []string{"SESSION_START"},
+		URL:        "https://example.com",
+	}}
+
+	if !reflect.DeepEqual(webhooks, expected) {
+		t.Errorf("expected %+v, got %+v", expected, webhooks)
+	}
+}
+
+func TestWebhooksService_Create(t *testing.T) {
+	client, mux := setup(t)
+
+	config := WebhookConfig{
+		Name:       "test",
+		OUCode:     "0000",
+		Enabled:    true,
+		EventTypes: []string{"SESSION_START"},
+		Headers:    []Header{{Name: "hello", Value: "world"}},
+		URL:        "https://example.com",
+	}
+
+	mux.HandleFunc("/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var got WebhookConfig
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("unexpected error decoding request body: %s", err)
+		}
+
+		if !reflect.DeepEqual(got, config) {
+			t.Errorf("expected request body %+v, got %+v", config, got)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1","name":"test","ouCode":"0000","enabled":true,"eventTypes":["SESSION_START"],"url":"https://example.com","headers":[{"name":"hello","value":"world"}]}`))
+	})
+
+	webhook, err := client.Webhooks.Create(context.Background(), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if webhook.ID != "1" || !reflect.DeepEqual(webhook.Headers, config.Headers) {
+		t.Errorf("unexpected webhook: %+v", webhook)
+	}
+}
+
+func TestWebhooksService_Update(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/webhooks/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		_, _ = w.Write([]byte(`{"id":"1","name":"test2"}`))
+	})
+
+	webhook, err := client.Webhooks.Update(context.Background(), "1", WebhookConfig{Name: "test2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if webhook.Name != "test2" {
+		t.Errorf("expected name %q, got %q", "test2", webhook.Name)
+	}
+}
+
+func TestWebhooksService_Delete(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v1/webhooks/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Webhooks.Delete(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestWebhooksService_ListDeliveries(t *testing.T) {
+	success := false
+
+	failed := WebhookDelivery{
+		ID:         "d1",
+		WebhookID:  "1",
+		EventType:  "SESSION_START",
+		Timestamp:  "2024-01-01T12:00:00Z",
+		Success:    false,
+		StatusCode: 500,
+		LatencyMs:  120,
+		Error:      "internal server error",
+	}
+	succeeded := WebhookDelivery{
+		ID:         "d2",
+		WebhookID:  "1",
+		EventType:  "SESSION_START",
+		Timestamp:  "2024-01-01T13:00:00Z",
+		Success:    true,
+		StatusCode: 200,
+		LatencyMs:  80,
+	}
+	otherEventType := WebhookDelivery{
+		ID:         "d3",
+		WebhookID:  "1",
+		EventType:  "SESSION_STOP",
+		Timestamp:  "2024-01-01T14:00:00Z",
+		Success:    false,
+		StatusCode: 502,
+		LatencyMs:  95,
+		Error:      "bad gateway",
+	}
+
+	testCases := map[string]struct {
+		opts     WebhookDeliveriesOptions
+		query    string
+		expected []WebhookDelivery
+	}{
+		"unfiltered": {
+			query:    "",
+			expected: []WebhookDelivery{failed, succeeded, otherEventType},
+		},
+		// The API may not apply the filters, so they are applied to the
+		// response as well.
+		"filtered": {
+			opts: WebhookDeliveriesOptions{
+				From:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2024, 1, 2, 1, 0, 0, 0, time.FixedZone("CET", 3600)),
+				EventType: "SESSION_START",
+				Success:   &success,
+			},
+			query:    "eventType=SESSION_START&from=2024-01-01T00%3A00%3A00Z&success=false&to=2024-01-02T00%3A00%3A00Z",
+			expected: []WebhookDelivery{failed},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, mux := setup(t)
+
+			mux.HandleFunc("/v1/webhooks/1/deliveries", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+
+				if r.URL.RawQuery != tc.query {
+					t.Errorf("expected query %q, got %q", tc.query, r.URL.RawQuery)
+				}
+
+				_ = json.NewEncoder(w).Encode([]WebhookDelivery{failed, succeeded, otherEventType})
+			})
+
+			deliveries, err := client.Webhooks.ListDeliveries(context.Background(), "1", tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(deliveries, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, deliveries)
+			}
+		})
+	}
+}
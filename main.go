@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
+	"github.com/cbcoutinho/terraform-provider-longship/internal/export"
 	"github.com/cbcoutinho/terraform-provider-longship/internal/provider"
 )
 
@@ -16,6 +19,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -31,9 +41,65 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
 
+	shutdownTracing, err := provider.SetupTracing(ctx, version)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	// Flush spans which are still buffered before exiting.
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("[WARN] Unable to export traces: %s", err)
+	}
+
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// runExport writes the Terraform configuration of an existing tenant,
+// including import blocks, to a directory.
+func runExport(args []string) error {
+	var dir, profile, configFile string
+	var force bool
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&dir, "dir", ".", "directory to write the generated .tf files to")
+	flags.StringVar(&profile, "profile", "", "profile of the Longship config file to use, defaults to LONGSHIP_PROFILE or default")
+	flags.StringVar(&configFile, "config-file", "", "path of the Longship config file, defaults to LONGSHIP_CONFIG_FILE or ~/.longship/config")
+	flags.BoolVar(&force, "force", false, "overwrite existing files")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes longship resources and import blocks for all objects of a tenant.")
+		fmt.Fprintln(flags.Output(), "Credentials are read from LONGSHIP_ environment variables or a profile.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := provider.NewClient(profile, configFile, version)
+	if err != nil {
+		return err
+	}
+
+	paths, warnings, err := export.Run(context.Background(), client, dir, force)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Println("Wrote", path)
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", warning)
+	}
+
+	return nil
 }